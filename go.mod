@@ -1,10 +1,11 @@
 module github.com/fraenky8/tables-to-go
 
-go 1.20
+go 1.21
 
 require (
 	github.com/go-sql-driver/mysql v1.7.1
 	github.com/iancoleman/strcase v0.2.0
+	github.com/jinzhu/inflection v1.0.0
 	github.com/jmoiron/sqlx v1.3.5
 	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.17
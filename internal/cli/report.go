@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fraenky8/tables-to-go/pkg/generator"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+)
+
+// These are the statuses of a report.
+const (
+	reportSuccess = "success"
+	reportPartial = "partial"
+	reportFailure = "failure"
+)
+
+// report is the machine-readable report of a run.
+type report struct {
+	// Status is either success, partial if anything got skipped with Force,
+	// or failure.
+	Status string        `json:"status"`
+	Error  string        `json:"error,omitempty"`
+	Tables []tableReport `json:"tables"`
+	// Warnings are the warnings of the run not belonging to a table or view.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// tableReport is the outcome of a table or view in the report.
+type tableReport struct {
+	Kind     string   `json:"kind"`
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Columns  int      `json:"columns"`
+	File     string   `json:"file,omitempty"`
+	Hash     string   `json:"hash,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// newReport creates the report of the result and error of a run. With a
+// FileWriter, the files are given by their paths and their written content
+// gets hashed with SHA-256.
+func newReport(result *generator.Result, runErr error, out output.Writer) (*report, error) {
+	r := &report{
+		Status: reportSuccess,
+		Tables: []tableReport{},
+	}
+
+	if result == nil {
+		result = &generator.Result{}
+	}
+	r.Warnings = result.Warnings
+
+	switch {
+	case runErr != nil:
+		r.Status = reportFailure
+		r.Error = runErr.Error()
+	case len(result.Skipped) > 0:
+		r.Status = reportPartial
+	}
+
+	fileWriter, isFileWriter := out.(*output.FileWriter)
+
+	for _, table := range result.Tables {
+		tr := tableReport{
+			Kind:     table.Kind,
+			Name:     table.Name,
+			Status:   table.Status,
+			Columns:  table.Columns,
+			File:     table.File,
+			Warnings: table.Warnings,
+			Error:    table.Error,
+		}
+
+		if tr.File != "" && isFileWriter {
+			tr.File = fileWriter.Path(table.File)
+
+			content, err := os.ReadFile(tr.File)
+			if err != nil {
+				return nil, fmt.Errorf("could not hash file of %s %q: %w", table.Kind, table.Name, err)
+			}
+			hash := sha256.Sum256(content)
+			tr.Hash = "sha256:" + hex.EncodeToString(hash[:])
+		}
+
+		r.Tables = append(r.Tables, tr)
+	}
+
+	return r, nil
+}
+
+// writeReport writes the report as JSON to the file of the path.
+func writeReport(path string, r *report) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0666)
+}
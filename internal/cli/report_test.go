@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/generator"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestNewReport(t *testing.T) {
+	dir := t.TempDir()
+	out := output.NewFileWriter(dir)
+	if err := out.Write("Users", "package dto\n\ntype Users struct{}\n"); err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "Users.go"))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	hash := sha256.Sum256(content)
+
+	generated := &generator.TableResult{
+		Kind:     "table",
+		Name:     "users",
+		Status:   generator.StatusGenerated,
+		Columns:  2,
+		File:     "Users",
+		Warnings: []string{`column "settings" has the unmapped data type "json"; falling back to string`},
+	}
+	skipped := &generator.TableResult{
+		Kind:   "view",
+		Name:   "orders",
+		Status: generator.StatusSkipped,
+		Error:  "could not get columns",
+	}
+
+	tests := []struct {
+		desc     string
+		result   *generator.Result
+		err      error
+		expected *report
+	}{
+		{
+			desc: "generated table with path and hash of file",
+			result: &generator.Result{
+				Files:  []string{"Users"},
+				Tables: []*generator.TableResult{generated},
+			},
+			expected: &report{
+				Status: reportSuccess,
+				Tables: []tableReport{
+					{
+						Kind:     "table",
+						Name:     "users",
+						Status:   generator.StatusGenerated,
+						Columns:  2,
+						File:     filepath.Join(dir, "Users.go"),
+						Hash:     "sha256:" + hex.EncodeToString(hash[:]),
+						Warnings: generated.Warnings,
+					},
+				},
+			},
+		},
+		{
+			desc: "skipped view is partial",
+			result: &generator.Result{
+				Skipped: []generator.Skipped{{Kind: "view", Name: "orders", Reason: "could not get columns"}},
+				Tables:  []*generator.TableResult{skipped},
+			},
+			expected: &report{
+				Status: reportPartial,
+				Tables: []tableReport{
+					{Kind: "view", Name: "orders", Status: generator.StatusSkipped, Error: "could not get columns"},
+				},
+			},
+		},
+		{
+			desc: "warnings of the run",
+			result: &generator.Result{
+				Warnings: []string{`rename "payments" does not match any table, view or column`},
+			},
+			expected: &report{
+				Status:   reportSuccess,
+				Tables:   []tableReport{},
+				Warnings: []string{`rename "payments" does not match any table, view or column`},
+			},
+		},
+		{
+			desc: "error without result is failure",
+			err:  errors.New("no writer given"),
+			expected: &report{
+				Status: reportFailure,
+				Error:  "no writer given",
+				Tables: []tableReport{},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, err := newReport(test.result, test.err, out)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNewReport_SQLFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := settings.New()
+	s.Force = true
+	s.SQLFiles = settings.StringList{filepath.Join(dir, "users.sql")}
+	err := os.WriteFile(s.SQLFiles[0], []byte("-- name: DeleteUser :exec\nDELETE FROM users WHERE id = $1;\n"), 0o600)
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	// The tables of a schema model can't describe the query, which gets
+	// skipped while the file is still written.
+	out := output.NewFileWriter(dir)
+	result, err := generator.Generate(context.Background(), generator.Options{
+		Settings: s,
+		Tables:   []*database.Table{},
+		Writer:   out,
+	})
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+
+	actual, err := newReport(result, nil, out)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "UsersQueries.go"))
+	if err != nil {
+		t.Fatalf("expected non error, got: %s", err)
+	}
+	hash := sha256.Sum256(content)
+
+	reason := `could not create query "DeleteUser": could not describe query: queries can't be described without a database`
+	assert.Equal(t, &report{
+		Status: reportPartial,
+		Tables: []tableReport{
+			{
+				Kind:     "file",
+				Name:     s.SQLFiles[0],
+				Status:   generator.StatusGenerated,
+				File:     filepath.Join(dir, "UsersQueries.go"),
+				Hash:     "sha256:" + hex.EncodeToString(hash[:]),
+				Warnings: []string{fmt.Sprintf("skipping query %q: %s", "DeleteUser", reason)},
+			},
+		},
+	}, actual)
+}
@@ -3,22 +3,14 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/fraenky8/tables-to-go/pkg/settings"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/microsoft/go-mssqldb"
 )
 
-var (
-	// dbTypeToDriverMap maps the database type to the driver names.
-	dbTypeToDriverMap = map[settings.DBType]string{
-		settings.DBTypePostgresql: "postgres",
-		settings.DBTypeMySQL:      "mysql",
-		settings.DBTypeSQLite:     "sqlite3",
-		settings.DBTypeMsSQL:      "sqlserver",
-	}
-)
-
 // Database interface for the concrete databases.
 type Database interface {
 	DSN() string
@@ -36,23 +28,69 @@ type Database interface {
 	IsAutoIncrement(column Column) bool
 	IsNullable(column Column) bool
 
+	// Deprecated: Data types are classified by the typemapper package, see
+	// typemapper.DialectOf. The methods get removed in a future release.
 	GetStringDatatypes() []string
+	// Deprecated: See GetStringDatatypes.
 	IsString(column Column) bool
-
+	// Deprecated: See GetStringDatatypes.
 	GetTextDatatypes() []string
+	// Deprecated: See GetStringDatatypes.
 	IsText(column Column) bool
-
+	// Deprecated: See GetStringDatatypes.
 	GetIntegerDatatypes() []string
+	// Deprecated: See GetStringDatatypes.
 	IsInteger(column Column) bool
-
+	// Deprecated: See GetStringDatatypes.
 	GetFloatDatatypes() []string
+	// Deprecated: See GetStringDatatypes.
 	IsFloat(column Column) bool
-
+	// Deprecated: See GetStringDatatypes.
 	GetTemporalDatatypes() []string
+	// Deprecated: See GetStringDatatypes.
 	IsTemporal(column Column) bool
+}
+
+// QueryDescriber is implemented by a Database which can describe the
+// parameters and result columns of queries.
+type QueryDescriber interface {
+	DescribeQuery(query *Query) (err error)
+}
+
+// UniqueDetector is implemented by a Database which knows the unique
+// constraints of columns.
+type UniqueDetector interface {
+	IsUnique(column Column) bool
+}
+
+// GeneratedDetector is implemented by a Database which knows the generated
+// columns.
+type GeneratedDetector interface {
+	IsGenerated(column Column) bool
+}
+
+// DescribeQuery describes the query with the database, or returns an error if
+// the database doesn't implement QueryDescriber.
+func DescribeQuery(db Database, query *Query) error {
+	describer, ok := db.(QueryDescriber)
+	if !ok {
+		return fmt.Errorf("database %T can't describe queries", db)
+	}
+	return describer.DescribeQuery(query)
+}
 
-	// TODO pg: bitstrings, enum, range, other special types
-	// TODO mysql: bit, enums, set
+// IsUnique returns true if the column has a unique constraint. It is false
+// if the database doesn't implement UniqueDetector.
+func IsUnique(db Database, column Column) bool {
+	detector, ok := db.(UniqueDetector)
+	return ok && detector.IsUnique(column)
+}
+
+// IsGenerated returns true if the column is a generated column. It is false
+// if the database doesn't implement GeneratedDetector.
+func IsGenerated(db Database, column Column) bool {
+	detector, ok := db.(GeneratedDetector)
+	return ok && detector.IsGenerated(column)
 }
 
 // Table has a name and a set (slice) of columns.
@@ -70,10 +108,54 @@ type Column struct {
 	IsNullable             string         `db:"is_nullable"`
 	CharacterMaximumLength sql.NullInt64  `db:"character_maximum_length"`
 	NumericPrecision       sql.NullInt64  `db:"numeric_precision"`
+	NumericScale           sql.NullInt64  `db:"numeric_scale"`
+	EnumValues             sql.NullString `db:"enum_values"`     // labels of enum types as comma separated SQL string literals, eg. 'a','b'
 	ColumnKey              string         `db:"column_key"`      // mysql specific
 	Extra                  string         `db:"extra"`           // mysql specific
 	ConstraintName         sql.NullString `db:"constraint_name"` // pg specific
 	ConstraintType         sql.NullString `db:"constraint_type"` // pg specific
+	Comment                sql.NullString `db:"column_comment"`  // pg and mysql specific
+	IsGenerated            string         `db:"is_generated"`    // pg and mssql specific
+	ScanType               reflect.Type   `db:"-"`               // result columns of queries only
+}
+
+// ValueType returns the type of the values of the column the driver scans
+// them into, without the nullable wrappers. It is nil if the scan type is
+// unknown.
+func (c Column) ValueType() reflect.Type {
+	return scanType(c.ScanType)
+}
+
+// EnumLabels returns the labels of the enum type of the column, parsed from
+// the SQL string literals of EnumValues. Labels may contain commas and
+// quotes.
+func (c Column) EnumLabels() []string {
+	if !c.EnumValues.Valid {
+		return nil
+	}
+
+	var labels []string
+	values := c.EnumValues.String
+	for i := 0; i < len(values); i++ {
+		if values[i] != '\'' {
+			continue
+		}
+		var label strings.Builder
+		for i++; i < len(values); i++ {
+			if values[i] == '\'' {
+				// a doubled quote is an escaped quote
+				if i+1 < len(values) && values[i+1] == '\'' {
+					i++
+				} else {
+					break
+				}
+			}
+			label.WriteByte(values[i])
+		}
+		labels = append(labels, label.String())
+	}
+
+	return labels
 }
 
 // GeneralDatabase represents a base "class" database - for all other concrete
@@ -86,25 +168,25 @@ type GeneralDatabase struct {
 	driver string
 }
 
-// New creates a new Database based on the given type in the settings.
-func New(s *settings.Settings) Database {
-
-	var db Database
-
-	switch s.DbType {
-	case settings.DBTypeSQLite:
-		db = NewSQLite(s)
-	case settings.DBTypeMsSQL:
-		db = NewMssql(s)
-	case settings.DBTypeMySQL:
-		db = NewMySQL(s)
-	case settings.DBTypePostgresql:
-		fallthrough
-	default:
-		db = NewPostgresql(s)
+// NewGeneralDatabase creates a new GeneralDatabase connecting with the given
+// database/sql driver. It is meant to be embedded by the concrete databases.
+func NewGeneralDatabase(s *settings.Settings, driver string) *GeneralDatabase {
+	return &GeneralDatabase{
+		Settings: s,
+		driver:   driver,
 	}
+}
 
-	return db
+// New creates a new Database based on the given type in the settings. Unknown
+// types fall back to Postgresql.
+func New(s *settings.Settings) Database {
+	factoriesMu.RLock()
+	factory, ok := factories[s.DbType]
+	if !ok {
+		factory = factories[settings.DBTypePostgresql]
+	}
+	factoriesMu.RUnlock()
+	return factory(s)
 }
 
 // Connect establishes a connection to the database with the given DSN.
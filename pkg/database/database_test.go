@@ -0,0 +1,66 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestColumn_EnumLabels(t *testing.T) {
+	tests := []struct {
+		desc     string
+		values   sql.NullString
+		expected []string
+	}{
+		{
+			desc:     "column without enum type has no labels",
+			values:   sql.NullString{},
+			expected: nil,
+		},
+		{
+			desc:     "labels are unquoted",
+			values:   sql.NullString{String: "'new','in progress','done'", Valid: true},
+			expected: []string{"new", "in progress", "done"},
+		},
+		{
+			desc:     "labels keep commas and escaped quotes",
+			values:   sql.NullString{String: "'a,b','it''s',''", Valid: true},
+			expected: []string{"a,b", "it's", ""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := Column{EnumValues: test.values}.EnumLabels()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestOptionalInterfaces(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+			db := New(&settings.Settings{DbType: dbType})
+			assert.Implements(t, (*QueryDescriber)(nil), db)
+			assert.Implements(t, (*UniqueDetector)(nil), db)
+			assert.Implements(t, (*GeneratedDetector)(nil), db)
+		})
+	}
+}
+
+// plainDatabase implements none of the optional interfaces.
+type plainDatabase struct {
+	Database
+}
+
+func TestOptionalInterfaces_Fallback(t *testing.T) {
+	db := plainDatabase{}
+	column := Column{Name: "id", ColumnKey: "UNI", IsGenerated: "ALWAYS"}
+
+	assert.False(t, IsUnique(db, column))
+	assert.False(t, IsGenerated(db, column))
+	assert.EqualError(t, DescribeQuery(db, &Query{Name: "ListUsers"}),
+		"database database.plainDatabase can't describe queries")
+}
@@ -0,0 +1,299 @@
+package database
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fraenky8/tables-to-go/pkg/runtime"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+var (
+	// nullScanTypes maps the nullable scan types to the types of their values.
+	nullScanTypes = map[reflect.Type]reflect.Type{
+		reflect.TypeOf(sql.NullString{}):  reflect.TypeOf(""),
+		reflect.TypeOf(sql.NullInt64{}):   reflect.TypeOf(int64(0)),
+		reflect.TypeOf(sql.NullInt32{}):   reflect.TypeOf(int32(0)),
+		reflect.TypeOf(sql.NullInt16{}):   reflect.TypeOf(int16(0)),
+		reflect.TypeOf(sql.NullByte{}):    reflect.TypeOf(byte(0)),
+		reflect.TypeOf(sql.NullFloat64{}): reflect.TypeOf(float64(0)),
+		reflect.TypeOf(sql.NullBool{}):    reflect.TypeOf(false),
+		reflect.TypeOf(sql.NullTime{}):    reflect.TypeOf(time.Time{}),
+	}
+)
+
+// Generic implements the Database interface for any registered database/sql
+// driver. The columns of the tables are inferred from the result columns of
+// a query instead of the schema, so constraints like primary keys are unknown.
+type Generic struct {
+	*GeneralDatabase
+}
+
+func init() {
+	Register(settings.DBTypeGeneric, func(s *settings.Settings) Database {
+		return NewGeneric(s)
+	}, "")
+}
+
+// NewGeneric creates a new Generic database using the driver of the settings.
+func NewGeneric(s *settings.Settings) *Generic {
+	return &Generic{
+		GeneralDatabase: NewGeneralDatabase(s, s.Driver),
+	}
+}
+
+// Connect connects to the database by the given data source name (dsn) of the
+// concrete database.
+func (g *Generic) Connect() error {
+	return g.GeneralDatabase.Connect(g.DSN())
+}
+
+// DSN returns the data source name of the settings, which is passed as is to
+// the driver.
+func (g *Generic) DSN() string {
+	return g.Settings.DataSource
+}
+
+// GetTables gets all tables by the tables query of the settings, or else of
+// the information schema. The first column of the query is the table name.
+func (g *Generic) GetTables() (tables []*Table, err error) {
+
+	rows, err := g.DB.Query(g.tablesQuery())
+	if err != nil {
+		g.Log().Debug("could not get tables", "query", g.tablesQuery(), "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var name string
+		values := make([]any, len(columns))
+		values[0] = &name
+		for i := 1; i < len(values); i++ {
+			values[i] = new(any)
+		}
+		if err = rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		tables = append(tables, &Table{Name: name})
+	}
+
+	return tables, rows.Err()
+}
+
+// tablesQuery returns the query of the names of the tables.
+func (g *Generic) tablesQuery() string {
+	if g.TablesQuery != "" {
+		return g.TablesQuery
+	}
+	// The syntax of the placeholders depends on the driver, so the schema
+	// is part of the query.
+	return `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_type = 'BASE TABLE'
+		AND table_schema = '` + strings.ReplaceAll(g.Schema, "'", "''") + `'
+		ORDER BY table_name
+	`
+}
+
+// GetViews returns no views, as they can't be told apart from tables.
+func (g *Generic) GetViews() (views []*Table, err error) {
+	return nil, nil
+}
+
+// PrepareGetColumnsOfTableStmt does nothing, as the columns of every table
+// get inferred by a query of its own.
+func (g *Generic) PrepareGetColumnsOfTableStmt() (err error) {
+	return nil
+}
+
+// PrepareGetColumnsOfViewStmt does nothing, see PrepareGetColumnsOfTableStmt.
+func (g *Generic) PrepareGetColumnsOfViewStmt() (err error) {
+	return nil
+}
+
+// GetColumnsOfTable infers the columns of the table by the result columns of
+// a query selecting no row. The table name gets quoted in the dialect, or
+// with double quotes of ANSI SQL without one.
+func (g *Generic) GetColumnsOfTable(table *Table) (err error) {
+
+	rows, err := g.DB.Query("SELECT * FROM " + runtime.Dialect(g.SQLDialect()).Quote(table.Name) + " WHERE 1 = 0")
+	if err != nil {
+		g.Log().Debug("could not get columns of table", "table", table.Name, "error", err)
+		return err
+	}
+	defer rows.Close()
+
+	table.Columns, err = resultColumns(rows, genericDataType)
+
+	return err
+}
+
+// GetColumnsOfView infers the columns of the view, see GetColumnsOfTable.
+func (g *Generic) GetColumnsOfView(view *Table) (err error) {
+	return g.GetColumnsOfTable(view)
+}
+
+// DescribeQuery describes the parameters and result columns of the query.
+func (g *Generic) DescribeQuery(query *Query) (err error) {
+	return g.describeQuery(query, genericDataType)
+}
+
+// genericDataType returns the data type of the result column. Booleans are
+// named uniformly, as the name of their type varies by database.
+func genericDataType(column *sql.ColumnType) string {
+	if scanType(column.ScanType()) == reflect.TypeOf(false) {
+		return "boolean"
+	}
+	return driverDataType(column)
+}
+
+// scanType returns the type of the values of the scan type of a column,
+// without the nullable wrappers.
+func scanType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	if held, ok := nullScanTypes[t]; ok {
+		return held
+	}
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// IsPrimaryKey returns false, as constraints are unknown.
+func (g *Generic) IsPrimaryKey(_ Column) bool {
+	return false
+}
+
+// IsAutoIncrement returns false, as defaults are unknown.
+func (g *Generic) IsAutoIncrement(_ Column) bool {
+	return false
+}
+
+// IsUnique returns false, as constraints are unknown.
+func (g *Generic) IsUnique(_ Column) bool {
+	return false
+}
+
+// IsGenerated returns false, as generated columns are unknown.
+func (g *Generic) IsGenerated(_ Column) bool {
+	return false
+}
+
+// GetStringDatatypes returns the string data types of the SQL standard.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) GetStringDatatypes() []string {
+	return []string{
+		"character varying",
+		"varchar",
+		"character",
+		"char",
+	}
+}
+
+// IsString returns true if the column is of a string data type.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) IsString(column Column) bool {
+	return isStringInSlice(column.DataType, g.GetStringDatatypes())
+}
+
+// GetTextDatatypes returns the text data types of the SQL standard.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) GetTextDatatypes() []string {
+	return []string{
+		"text",
+		"clob",
+	}
+}
+
+// IsText returns true if the column is of a text data type.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) IsText(column Column) bool {
+	return isStringInSlice(column.DataType, g.GetTextDatatypes())
+}
+
+// GetIntegerDatatypes returns the integer data types of the SQL standard.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) GetIntegerDatatypes() []string {
+	return []string{
+		"smallint",
+		"integer",
+		"int",
+		"bigint",
+	}
+}
+
+// IsInteger returns true if the column is of an integer data type.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) IsInteger(column Column) bool {
+	return isStringInSlice(column.DataType, g.GetIntegerDatatypes())
+}
+
+// GetFloatDatatypes returns the float data types of the SQL standard.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) GetFloatDatatypes() []string {
+	return []string{
+		"numeric",
+		"decimal",
+		"real",
+		"float",
+		"double precision",
+	}
+}
+
+// IsFloat returns true if the column is of a float data type.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) IsFloat(column Column) bool {
+	return isStringInSlice(column.DataType, g.GetFloatDatatypes())
+}
+
+// GetTemporalDatatypes returns the temporal data types of the SQL standard.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) GetTemporalDatatypes() []string {
+	return []string{
+		"date",
+		"time",
+		"timestamp",
+		"time with time zone",
+		"timestamp with time zone",
+		"time without time zone",
+		"timestamp without time zone",
+	}
+}
+
+// IsTemporal returns true if the column is of a temporal data type.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
+func (g *Generic) IsTemporal(column Column) bool {
+	return isStringInSlice(column.DataType, g.GetTemporalDatatypes())
+}
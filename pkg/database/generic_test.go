@@ -0,0 +1,42 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestGeneric_tablesQuery(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "tables query of the settings",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TablesQuery = "SHOW TABLES"
+				return s
+			},
+			expected: "SHOW TABLES",
+		},
+		{
+			desc: "information schema with escaped schema",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.Schema = "o'neil"
+				return s
+			},
+			expected: "AND table_schema = 'o''neil'",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			db := NewGeneric(test.settings())
+			assert.Contains(t, db.tablesQuery(), test.expected)
+		})
+	}
+}
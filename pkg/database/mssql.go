@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"strconv"
 	"strings"
 )
 
@@ -13,12 +14,15 @@ type MsSQL struct {
 	defaultUserName string
 }
 
+func init() {
+	Register(settings.DBTypeMsSQL, func(s *settings.Settings) Database {
+		return NewMssql(s)
+	}, "1433")
+}
+
 func NewMssql(s *settings.Settings) *MsSQL {
 	return &MsSQL{
-		GeneralDatabase: &GeneralDatabase{
-			Settings: s,
-			driver:   dbTypeToDriverMap[s.DbType],
-		},
+		GeneralDatabase: NewGeneralDatabase(s, "sqlserver"),
 		defaultUserName: "root",
 	}
 }
@@ -44,11 +48,8 @@ func (mssql *MsSQL) GetTables() (tables []*Table, err error) {
     ORDER BY table_name
 `, mssql.DbName)
 
-	if mssql.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", mssql.DbName)
-		}
+	if err != nil {
+		mssql.Log().Debug("could not get tables", "schema", mssql.DbName, "error", err)
 	}
 
 	return tables, err
@@ -58,32 +59,139 @@ func (mssql *MsSQL) PrepareGetColumnsOfTableStmt() (err error) {
 
 	mssql.GetColumnsOfTableStmt, err = mssql.Preparex(`
         SELECT
-          ordinal_position,
-          column_name,
-          data_type,
-          column_default,
-          is_nullable,
-          character_maximum_length,
-          numeric_precision
-          -- Note: MSSQL doesn't have direct equivalents for 'column_key' and 'extra'
-        FROM information_schema.columns
-        WHERE table_name = @TableName
-        ORDER BY ordinal_position
+          c.ordinal_position,
+          c.column_name,
+          c.data_type,
+          c.column_default,
+          c.is_nullable,
+          c.character_maximum_length,
+          c.numeric_precision,
+          c.numeric_scale,
+          CASE
+            WHEN EXISTS (
+              SELECT 1
+              FROM sys.indexes AS i
+              JOIN sys.index_columns AS ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+              WHERE i.object_id = OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name))
+              AND i.is_primary_key = 1
+              AND COL_NAME(ic.object_id, ic.column_id) = c.column_name
+            ) THEN 'PRI'
+            WHEN EXISTS (
+              SELECT 1
+              FROM sys.indexes AS i
+              JOIN sys.index_columns AS ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+              WHERE i.object_id = OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name))
+              AND i.is_unique = 1
+              AND COL_NAME(ic.object_id, ic.column_id) = c.column_name
+              AND ic.is_included_column = 0
+              -- like MySQL only single column unique indexes mark the column as unique
+              AND (SELECT COUNT(*) FROM sys.index_columns AS uc WHERE uc.object_id = i.object_id AND uc.index_id = i.index_id AND uc.is_included_column = 0) = 1
+            ) THEN 'UNI'
+            ELSE ''
+          END AS column_key,
+          CASE
+            WHEN EXISTS (
+              SELECT 1
+              FROM sys.identity_columns AS idc
+              WHERE idc.object_id = OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name))
+              AND idc.name = c.column_name
+            ) THEN 'auto_increment'
+            ELSE ''
+          END AS extra,
+          COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name)), c.column_name, 'IsComputed') AS is_generated
+        FROM information_schema.columns AS c
+        WHERE c.table_name = @TableName
+        ORDER BY c.ordinal_position
     `)
 	return err
 }
 
 func (mssql *MsSQL) GetColumnsOfTable(table *Table) (err error) {
 	err = mssql.GetColumnsOfTableStmt.Select(&table.Columns, sql.Named("TableName", table.Name))
-	if mssql.Settings.Verbose {
+	if err != nil {
+		mssql.Log().Debug("could not get columns of table", "table", table.Name, "schema", mssql.Schema, "db_name", mssql.DbName, "error", err)
+	}
+
+	return err
+}
+
+// mssqlParam is a parameter described by sp_describe_undeclared_parameters.
+type mssqlParam struct {
+	Name     string `db:"name"`
+	DataType string `db:"suggested_system_type_name"`
+}
+
+// mssqlResultColumn is a result column described by
+// sp_describe_first_result_set.
+type mssqlResultColumn struct {
+	IsHidden   bool           `db:"is_hidden"`
+	Name       sql.NullString `db:"name"`
+	IsNullable bool           `db:"is_nullable"`
+	DataType   sql.NullString `db:"system_type_name"`
+}
+
+// DescribeQuery describes the parameters and result columns of the query by
+// the system procedures describing statements without executing them. The
+// data types of the parameters are the ones the database derives for them.
+func (mssql *MsSQL) DescribeQuery(query *Query) (err error) {
+	query.Params = queryParams(mssql.DbType, query.SQL)
+
+	var params []mssqlParam
+	if len(query.Params) > 0 {
+		err = mssql.Unsafe().Select(&params, "EXEC sp_describe_undeclared_parameters @tsql = @p1", query.SQL)
 		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", mssql.Schema)
-			fmt.Printf("> dbName: %q\r\n", mssql.DbName)
+			return err
 		}
 	}
 
-	return err
+	declarations := make([]string, 0, len(params))
+	for _, param := range params {
+		declarations = append(declarations, param.Name+" "+param.DataType)
+		n, err := strconv.Atoi(strings.TrimPrefix(param.Name, "@p"))
+		if err == nil && n >= 1 && n <= len(query.Params) {
+			query.Params[n-1].DataType = mssqlDataType(param.DataType)
+		}
+	}
+
+	if !query.Kind.HasRows() {
+		return nil
+	}
+
+	var columns []mssqlResultColumn
+	if len(declarations) > 0 {
+		err = mssql.Unsafe().Select(&columns, "EXEC sp_describe_first_result_set @tsql = @p1, @params = @p2", query.SQL, strings.Join(declarations, ", "))
+	} else {
+		err = mssql.Unsafe().Select(&columns, "EXEC sp_describe_first_result_set @tsql = @p1", query.SQL)
+	}
+	if err != nil {
+		return err
+	}
+
+	query.Columns = make([]Column, 0, len(columns))
+	for _, column := range columns {
+		if column.IsHidden {
+			continue
+		}
+		isNullable := "NO"
+		if column.IsNullable {
+			isNullable = "YES"
+		}
+		query.Columns = append(query.Columns, Column{
+			OrdinalPosition: len(query.Columns) + 1,
+			Name:            column.Name.String,
+			DataType:        mssqlDataType(column.DataType.String),
+			IsNullable:      isNullable,
+		})
+	}
+
+	return nil
+}
+
+// mssqlDataType returns the data type of the system type name without its
+// length, precision or scale, eg. nvarchar for nvarchar(50).
+func mssqlDataType(systemTypeName string) string {
+	dataType, _, _ := strings.Cut(systemTypeName, "(")
+	return strings.ToLower(strings.TrimSpace(dataType))
 }
 
 func (mssql *MsSQL) GetViews() (views []*Table, err error) {
@@ -94,11 +202,8 @@ func (mssql *MsSQL) GetViews() (views []*Table, err error) {
         ORDER BY table_name
     `, mssql.DbName)
 
-	if mssql.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetViews()")
-			fmt.Printf("> schema: %q\r\n", mssql.DbName)
-		}
+	if err != nil {
+		mssql.Log().Debug("could not get views", "schema", mssql.DbName, "error", err)
 	}
 
 	return views, err
@@ -113,7 +218,8 @@ func (mssql *MsSQL) PrepareGetColumnsOfViewStmt() (err error) {
           column_default,
           is_nullable,
           character_maximum_length,
-          numeric_precision
+          numeric_precision,
+          numeric_scale
         FROM information_schema.columns
         WHERE table_name = @ViewName
         ORDER BY ordinal_position
@@ -123,12 +229,8 @@ func (mssql *MsSQL) PrepareGetColumnsOfViewStmt() (err error) {
 
 func (mssql *MsSQL) GetColumnsOfView(view *Table) (err error) {
 	err = mssql.GetColumnsOfViewStmt.Select(&view.Columns, sql.Named("ViewName", view.Name))
-	if mssql.Settings.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfView(%v)\r\n", view.Name)
-			fmt.Printf("> schema: %q\r\n", mssql.Schema)
-			fmt.Printf("> dbName: %q\r\n", mssql.DbName)
-		}
+	if err != nil {
+		mssql.Log().Debug("could not get columns of view", "view", view.Name, "schema", mssql.Schema, "db_name", mssql.DbName, "error", err)
 	}
 
 	return err
@@ -142,6 +244,16 @@ func (mssql *MsSQL) IsAutoIncrement(column Column) bool {
 	return strings.Contains(column.Extra, "auto_increment")
 }
 
+func (mssql *MsSQL) IsUnique(column Column) bool {
+	return strings.Contains(column.ColumnKey, "UNI")
+}
+
+func (mssql *MsSQL) IsGenerated(column Column) bool {
+	return column.IsGenerated == "1"
+}
+
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) GetStringDatatypes() []string {
 	return []string{
 		"char",
@@ -151,10 +263,14 @@ func (mssql *MsSQL) GetStringDatatypes() []string {
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) IsString(column Column) bool {
 	return isStringInSlice(column.DataType, mssql.GetStringDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) GetTextDatatypes() []string {
 	return []string{
 		"text",
@@ -162,10 +278,14 @@ func (mssql *MsSQL) GetTextDatatypes() []string {
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) IsText(column Column) bool {
 	return isStringInSlice(column.DataType, mssql.GetTextDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) GetIntegerDatatypes() []string {
 	return []string{
 		"tinyint",
@@ -176,10 +296,14 @@ func (mssql *MsSQL) GetIntegerDatatypes() []string {
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) IsInteger(column Column) bool {
 	return isStringInSlice(column.DataType, mssql.GetIntegerDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) GetFloatDatatypes() []string {
 	return []string{
 		"numeric",
@@ -189,10 +313,14 @@ func (mssql *MsSQL) GetFloatDatatypes() []string {
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) IsFloat(column Column) bool {
 	return isStringInSlice(column.DataType, mssql.GetFloatDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) GetTemporalDatatypes() []string {
 	return []string{
 		"time",
@@ -204,6 +332,8 @@ func (mssql *MsSQL) GetTemporalDatatypes() []string {
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mssql *MsSQL) IsTemporal(column Column) bool {
 	return isStringInSlice(column.DataType, mssql.GetTemporalDatatypes())
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 
@@ -32,13 +33,16 @@ func (mysql *MySQL) GetColumnsOfView(table *Table) (err error) {
 	panic("implement me")
 }
 
+func init() {
+	Register(settings.DBTypeMySQL, func(s *settings.Settings) Database {
+		return NewMySQL(s)
+	}, "3306")
+}
+
 // NewMySQL creates a new MySQL database.
 func NewMySQL(s *settings.Settings) *MySQL {
 	return &MySQL{
-		GeneralDatabase: &GeneralDatabase{
-			Settings: s,
-			driver:   dbTypeToDriverMap[s.DbType],
-		},
+		GeneralDatabase: NewGeneralDatabase(s, "mysql"),
 		defaultUserName: "root",
 	}
 }
@@ -75,11 +79,8 @@ func (mysql *MySQL) GetTables() (tables []*Table, err error) {
 		ORDER BY table_name
 	`, mysql.DbName)
 
-	if mysql.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", mysql.DbName)
-		}
+	if err != nil {
+		mysql.Log().Debug("could not get tables", "schema", mysql.DbName, "error", err)
 	}
 
 	return tables, err
@@ -98,6 +99,9 @@ func (mysql *MySQL) PrepareGetColumnsOfTableStmt() (err error) {
 		  is_nullable AS is_nullable,
 		  character_maximum_length AS character_maximum_length,
 		  numeric_precision AS numeric_precision,
+		  numeric_scale AS numeric_scale,
+		  IF(data_type = 'enum', SUBSTRING(column_type, 6, CHAR_LENGTH(column_type) - 6), NULL) AS enum_values,
+		  NULLIF(column_comment, '') AS column_comment,
 		  column_key AS column_key,
 		  extra AS extra
 		FROM information_schema.columns
@@ -115,17 +119,24 @@ func (mysql *MySQL) GetColumnsOfTable(table *Table) (err error) {
 
 	err = mysql.GetColumnsOfTableStmt.Select(&table.Columns, table.Name, mysql.DbName)
 
-	if mysql.Settings.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", mysql.Schema)
-			fmt.Printf("> dbName: %q\r\n", mysql.DbName)
-		}
+	if err != nil {
+		mysql.Log().Debug("could not get columns of table", "table", table.Name, "schema", mysql.Schema, "db_name", mysql.DbName, "error", err)
 	}
 
 	return err
 }
 
+// DescribeQuery describes the parameters and result columns of the query.
+func (mysql *MySQL) DescribeQuery(query *Query) (err error) {
+	return mysql.describeQuery(query, mysqlDataType)
+}
+
+// mysqlDataType returns the data type of the result column. The driver
+// prefixes the unsigned integer types.
+func mysqlDataType(column *sql.ColumnType) string {
+	return strings.TrimPrefix(driverDataType(column), "unsigned ")
+}
+
 // IsPrimaryKey checks if the column belongs to the primary key.
 func (mysql *MySQL) IsPrimaryKey(column Column) bool {
 	return strings.Contains(column.ColumnKey, "PRI")
@@ -136,7 +147,21 @@ func (mysql *MySQL) IsAutoIncrement(column Column) bool {
 	return strings.Contains(column.Extra, "auto_increment")
 }
 
+// IsUnique checks if the column has a unique index.
+func (mysql *MySQL) IsUnique(column Column) bool {
+	return strings.Contains(column.ColumnKey, "UNI")
+}
+
+// IsGenerated checks if the column is a virtual or stored generated column.
+func (mysql *MySQL) IsGenerated(column Column) bool {
+	return strings.Contains(column.Extra, "VIRTUAL GENERATED") ||
+		strings.Contains(column.Extra, "STORED GENERATED")
+}
+
 // GetStringDatatypes returns the string datatypes for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) GetStringDatatypes() []string {
 	return []string{
 		"char",
@@ -147,11 +172,17 @@ func (mysql *MySQL) GetStringDatatypes() []string {
 }
 
 // IsString returns true if the colum is of type string for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) IsString(column Column) bool {
 	return isStringInSlice(column.DataType, mysql.GetStringDatatypes())
 }
 
 // GetTextDatatypes returns the text datatypes for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) GetTextDatatypes() []string {
 	return []string{
 		"text",
@@ -160,11 +191,17 @@ func (mysql *MySQL) GetTextDatatypes() []string {
 }
 
 // IsText returns true if colum is of type text for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) IsText(column Column) bool {
 	return isStringInSlice(column.DataType, mysql.GetTextDatatypes())
 }
 
 // GetIntegerDatatypes returns the integer datatypes for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) GetIntegerDatatypes() []string {
 	return []string{
 		"tinyint",
@@ -176,11 +213,17 @@ func (mysql *MySQL) GetIntegerDatatypes() []string {
 }
 
 // IsInteger returns true if colum is of type integer for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) IsInteger(column Column) bool {
 	return isStringInSlice(column.DataType, mysql.GetIntegerDatatypes())
 }
 
 // GetFloatDatatypes returns the float datatypes for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) GetFloatDatatypes() []string {
 	return []string{
 		"numeric",
@@ -192,11 +235,17 @@ func (mysql *MySQL) GetFloatDatatypes() []string {
 }
 
 // IsFloat returns true if colum is of type float for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) IsFloat(column Column) bool {
 	return isStringInSlice(column.DataType, mysql.GetFloatDatatypes())
 }
 
 // GetTemporalDatatypes returns the temporal datatypes for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) GetTemporalDatatypes() []string {
 	return []string{
 		"time",
@@ -208,6 +257,9 @@ func (mysql *MySQL) GetTemporalDatatypes() []string {
 }
 
 // IsTemporal returns true if colum is of type temporal for the MySQL database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (mysql *MySQL) IsTemporal(column Column) bool {
 	return isStringInSlice(column.DataType, mysql.GetTemporalDatatypes())
 }
@@ -1,13 +1,14 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
-	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/lib/pq"
 
-	// postgres database driver
-	_ "github.com/lib/pq"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
 )
 
 // Postgresql implements the Database interface with help of GeneralDatabase.
@@ -32,13 +33,16 @@ func (pg *Postgresql) GetColumnsOfView(table *Table) (err error) {
 	panic("implement me")
 }
 
+func init() {
+	Register(settings.DBTypePostgresql, func(s *settings.Settings) Database {
+		return NewPostgresql(s)
+	}, "5432")
+}
+
 // NewPostgresql creates a new Postgresql database.
 func NewPostgresql(s *settings.Settings) *Postgresql {
 	return &Postgresql{
-		GeneralDatabase: &GeneralDatabase{
-			Settings: s,
-			driver:   dbTypeToDriverMap[s.DbType],
-		},
+		GeneralDatabase: NewGeneralDatabase(s, "postgres"),
 		defaultUserName: "postgres",
 	}
 }
@@ -74,11 +78,8 @@ func (pg *Postgresql) GetTables() (tables []*Table, err error) {
 		ORDER BY table_name
 	`, pg.Schema)
 
-	if pg.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> schema: %q\r\n", pg.Schema)
-		}
+	if err != nil {
+		pg.Log().Debug("could not get tables", "schema", pg.Schema, "error", err)
 	}
 
 	return tables, err
@@ -97,6 +98,15 @@ func (pg *Postgresql) PrepareGetColumnsOfTableStmt() (err error) {
 			ic.is_nullable,
 			ic.character_maximum_length,
 			ic.numeric_precision,
+			ic.numeric_scale,
+			ic.is_generated,
+			(
+				SELECT string_agg('''' || replace(pe.enumlabel, '''', '''''') || '''', ',' ORDER BY pe.enumsortorder)
+				FROM pg_catalog.pg_type AS pt
+					JOIN pg_catalog.pg_enum AS pe ON pe.enumtypid = pt.oid
+				WHERE pt.typname = ic.udt_name
+			) AS enum_values,
+			col_description(format('%I.%I', ic.table_schema, ic.table_name)::regclass, ic.ordinal_position::int) AS column_comment,
 			itc.constraint_name,
 			itc.constraint_type
 		FROM information_schema.columns AS ic
@@ -120,16 +130,130 @@ func (pg *Postgresql) GetColumnsOfTable(table *Table) (err error) {
 
 	err = pg.GetColumnsOfTableStmt.Select(&table.Columns, table.Name, pg.Schema)
 
-	if pg.Verbose {
-		if err != nil {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> schema: %q\r\n", pg.Schema)
+	if err != nil {
+		pg.Log().Debug("could not get columns of table", "table", table.Name, "schema", pg.Schema, "error", err)
+	}
+
+	return err
+}
+
+// DescribeQuery describes the parameters and result columns of the query. The
+// data types of the parameters get derived by the database from the prepared
+// statement.
+func (pg *Postgresql) DescribeQuery(query *Query) (err error) {
+
+	query.Params = queryParams(pg.DbType, query.SQL)
+	if len(query.Params) > 0 {
+		if err = pg.describeParams(query); err != nil {
+			return err
 		}
 	}
 
+	if !query.Kind.HasRows() {
+		return nil
+	}
+
+	return pg.describeColumns(query)
+}
+
+// describeColumns describes the result columns of the query by a cursor. The
+// cursor only gets declared, which plans the query without executing it, and
+// fetching no row of it returns the description of the result. Cursors can
+// only be declared for SELECT and VALUES statements.
+func (pg *Postgresql) describeColumns(query *Query) (err error) {
+	ctx := context.Background()
+
+	tx, err := pg.BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(ctx, "DECLARE tables_to_go_describe NO SCROLL CURSOR FOR "+query.SQL, make([]any, len(query.Params))...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx, "FETCH FORWARD 0 FROM tables_to_go_describe")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	query.Columns, err = resultColumns(rows, pgDataType)
+
 	return err
 }
 
+// describeParams sets the data types of the parameters of the query by the
+// ones the database derived for the prepared statement.
+func (pg *Postgresql) describeParams(query *Query) (err error) {
+	ctx := context.Background()
+
+	// The prepared statement only exists in the session of its connection.
+	conn, err := pg.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "PREPARE tables_to_go_describe AS "+query.SQL); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.ExecContext(ctx, "DEALLOCATE tables_to_go_describe")
+	}()
+
+	var dataTypes pq.StringArray
+	err = conn.QueryRowxContext(ctx, `
+		SELECT parameter_types::text[]
+		FROM pg_prepared_statements
+		WHERE name = 'tables_to_go_describe'
+	`).Scan(&dataTypes)
+	if err != nil {
+		return err
+	}
+
+	for i := range query.Params {
+		if i < len(dataTypes) {
+			query.Params[i].DataType = dataTypes[i]
+		}
+	}
+
+	return nil
+}
+
+// pgDataTypes maps the type names of the driver to the data types of the
+// information schema.
+var pgDataTypes = map[string]string{
+	"INT2":        "smallint",
+	"INT4":        "integer",
+	"INT8":        "bigint",
+	"FLOAT4":      "real",
+	"FLOAT8":      "double precision",
+	"NUMERIC":     "numeric",
+	"VARCHAR":     "character varying",
+	"BPCHAR":      "character",
+	"TEXT":        "text",
+	"UUID":        "uuid",
+	"BOOL":        "boolean",
+	"DATE":        "date",
+	"TIME":        "time without time zone",
+	"TIMETZ":      "time with time zone",
+	"TIMESTAMP":   "timestamp without time zone",
+	"TIMESTAMPTZ": "timestamp with time zone",
+}
+
+// pgDataType returns the data type of the result column.
+func pgDataType(column *sql.ColumnType) string {
+	if dataType, ok := pgDataTypes[column.DatabaseTypeName()]; ok {
+		return dataType
+	}
+	return driverDataType(column)
+}
+
 // IsPrimaryKey checks if the column belongs to the primary key.
 func (pg *Postgresql) IsPrimaryKey(column Column) bool {
 	return strings.Contains(column.ConstraintType.String, "PRIMARY KEY")
@@ -140,7 +264,20 @@ func (pg *Postgresql) IsAutoIncrement(column Column) bool {
 	return strings.Contains(column.DefaultValue.String, "nextval")
 }
 
+// IsUnique checks if the column has a unique constraint.
+func (pg *Postgresql) IsUnique(column Column) bool {
+	return strings.Contains(column.ConstraintType.String, "UNIQUE")
+}
+
+// IsGenerated checks if the column is a generated column.
+func (pg *Postgresql) IsGenerated(column Column) bool {
+	return column.IsGenerated == "ALWAYS"
+}
+
 // GetStringDatatypes returns the string datatypes for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) GetStringDatatypes() []string {
 	return []string{
 		"character varying",
@@ -152,11 +289,17 @@ func (pg *Postgresql) GetStringDatatypes() []string {
 }
 
 // IsString returns true if colum is of type string for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) IsString(column Column) bool {
 	return isStringInSlice(column.DataType, pg.GetStringDatatypes())
 }
 
 // GetTextDatatypes returns the text datatypes for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) GetTextDatatypes() []string {
 	return []string{
 		"text",
@@ -164,11 +307,17 @@ func (pg *Postgresql) GetTextDatatypes() []string {
 }
 
 // IsText returns true if colum is of type text for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) IsText(column Column) bool {
 	return isStringInSlice(column.DataType, pg.GetTextDatatypes())
 }
 
 // GetIntegerDatatypes returns the integer datatypes for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) GetIntegerDatatypes() []string {
 	return []string{
 		"smallint",
@@ -181,11 +330,17 @@ func (pg *Postgresql) GetIntegerDatatypes() []string {
 }
 
 // IsInteger returns true if colum is of type integer for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) IsInteger(column Column) bool {
 	return isStringInSlice(column.DataType, pg.GetIntegerDatatypes())
 }
 
 // GetFloatDatatypes returns the float datatypes for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) GetFloatDatatypes() []string {
 	return []string{
 		"numeric",
@@ -196,11 +351,17 @@ func (pg *Postgresql) GetFloatDatatypes() []string {
 }
 
 // IsFloat returns true if colum is of type float for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) IsFloat(column Column) bool {
 	return isStringInSlice(column.DataType, pg.GetFloatDatatypes())
 }
 
 // GetTemporalDatatypes returns the temporal datatypes for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) GetTemporalDatatypes() []string {
 	return []string{
 		"time",
@@ -214,6 +375,9 @@ func (pg *Postgresql) GetTemporalDatatypes() []string {
 }
 
 // IsTemporal returns true if colum is of type temporal for the Postgresql database.
+//
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (pg *Postgresql) IsTemporal(column Column) bool {
 	return isStringInSlice(column.DataType, pg.GetTemporalDatatypes())
 }
@@ -0,0 +1,381 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// QueryKind is the kind of result a query is executed for.
+type QueryKind string
+
+// These are the supported kinds of queries.
+const (
+	QueryKindOne      QueryKind = ":one"
+	QueryKindMany     QueryKind = ":many"
+	QueryKindExec     QueryKind = ":exec"
+	QueryKindExecRows QueryKind = ":execrows"
+)
+
+// HasRows returns true if the query is executed for its result rows.
+func (k QueryKind) HasRows() bool {
+	return k == QueryKindOne || k == QueryKindMany
+}
+
+// Query is a hand-written statement of a SQL file, annotated by a line like
+// `-- name: GetUserByEmail :one`.
+type Query struct {
+	Name string
+	Kind QueryKind
+	SQL  string
+
+	// Params are the parameters of the statement in their order. Their name
+	// is the column they get compared with, if any, and their data type is
+	// empty if the database can't tell.
+	Params []Column
+	// Columns are the columns of the result rows.
+	Columns []Column
+}
+
+var (
+	// queryNameRegexp matches the annotation of a query.
+	queryNameRegexp = regexp.MustCompile(`^--\s*name:\s*(\S+)\s+(\S+)\s*$`)
+	// paramColumnRegexp matches the column a parameter gets compared with at
+	// the end of the preceding SQL, eg. `u.email = `.
+	paramColumnRegexp = regexp.MustCompile(`(?i)([a-z_][a-z0-9_]*)["\x60\]]?\s*(?:=|<>|!=|<=|>=|<|>|\s+i?like|\s+in\s*\()\s*$`)
+	// insertRegexp matches the columns and values of an insert statement.
+	insertRegexp = regexp.MustCompile(`(?is)^\s*insert\s+into\s+\S+\s*\(([^)]*)\)\s*values\s*\(([^)]*)\)`)
+)
+
+// ParseQueries parses the queries of the content of a SQL file. Every query
+// starts with its annotation and ends at the next one.
+func ParseQueries(content string) (queries []*Query, err error) {
+	var query *Query
+	var body strings.Builder
+
+	finish := func() error {
+		if query == nil {
+			return nil
+		}
+		query.SQL = strings.TrimRight(strings.TrimSpace(body.String()), ";")
+		if query.SQL == "" {
+			return fmt.Errorf("query %q has no statement", query.Name)
+		}
+		queries = append(queries, query)
+		body.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+
+		if m := queryNameRegexp.FindStringSubmatch(trimmed); m != nil {
+			if err = finish(); err != nil {
+				return nil, err
+			}
+			kind := QueryKind(m[2])
+			switch kind {
+			case QueryKindOne, QueryKindMany, QueryKindExec, QueryKindExecRows:
+			default:
+				return nil, fmt.Errorf("query %q in line %d has unknown kind %q", m[1], line, kind)
+			}
+			query = &Query{Name: m[1], Kind: kind}
+			continue
+		}
+
+		if query == nil {
+			if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+				return nil, fmt.Errorf("statement in line %d has no name annotation", line)
+			}
+			continue
+		}
+
+		body.WriteString(text)
+		body.WriteString("\n")
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err = finish(); err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// queryParams returns the parameters of the statement by its placeholders in
+// the syntax of the database. The name of a parameter is the column it gets
+// inserted into or compared with first.
+func queryParams(dbType settings.DBType, query string) []Column {
+	var params []Column
+
+	// The values of an insert are named by the columns at the same position.
+	insertColumns := map[int]string{}
+	if m := insertRegexp.FindStringSubmatch(query); m != nil {
+		columns, values := strings.Split(m[1], ","), strings.Split(m[2], ",")
+		for i, n := 0, 0; i < len(values) && len(columns) == len(values); i++ {
+			value := strings.TrimSpace(values[i])
+			column := strings.Trim(strings.TrimSpace(columns[i]), "\"`[]")
+			switch {
+			case value == "?":
+				n++
+				insertColumns[n] = column
+			case strings.HasPrefix(value, "$"):
+				if number, err := strconv.Atoi(value[1:]); err == nil {
+					insertColumns[number] = column
+				}
+			case strings.HasPrefix(value, "@p"):
+				if number, err := strconv.Atoi(value[2:]); err == nil {
+					insertColumns[number] = column
+				}
+			}
+		}
+	}
+
+	add := func(n, pos int) {
+		for len(params) < n {
+			params = append(params, Column{OrdinalPosition: len(params) + 1})
+		}
+		if params[n-1].Name != "" {
+			return
+		}
+		if column, ok := insertColumns[n]; ok {
+			params[n-1].Name = column
+		} else if m := paramColumnRegexp.FindStringSubmatch(query[:pos]); m != nil {
+			params[n-1].Name = m[1]
+		}
+	}
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			// Skip string literals and quoted identifiers.
+			if end := strings.IndexByte(query[i+1:], c); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(query)
+			}
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(query)
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			if end := strings.Index(query[i:], "*/"); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(query)
+			}
+		case c == '?' && (dbType == settings.DBTypeMySQL || dbType == settings.DBTypeSQLite):
+			add(len(params)+1, i)
+		case c == '$' && dbType == settings.DBTypePostgresql,
+			c == '@' && dbType == settings.DBTypeMsSQL && strings.HasPrefix(query[i:], "@p"):
+			start := i + 1
+			if c == '@' {
+				start++
+			}
+			end := start
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+			n, err := strconv.Atoi(query[start:end])
+			if err != nil || n < 1 {
+				continue
+			}
+			add(n, i)
+			i = end - 1
+		}
+	}
+
+	return params
+}
+
+// describeQuery describes the parameters and result columns of the query.
+// The query never gets executed: the result columns get described by the
+// statement limited to no rows in a read-only transaction, so only SELECT
+// statements can be described. The placeholders of the cut off clauses, eg.
+// of `LIMIT ? OFFSET ?`, get no arguments. The data types of the columns are
+// the type names of the driver mapped by dataType.
+func (gdb *GeneralDatabase) describeQuery(query *Query, dataType func(column *sql.ColumnType) string) (err error) {
+	if query.Params == nil {
+		query.Params = queryParams(gdb.SQLDialect(), query.SQL)
+	}
+
+	if !query.Kind.HasRows() {
+		return nil
+	}
+
+	if !isSelect(query.SQL) {
+		return fmt.Errorf("only SELECT statements can be described without executing them")
+	}
+
+	tx, err := gdb.BeginTxx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	statement := limitZero(gdb.SQLDialect(), query.SQL)
+	args := make([]any, len(queryParams(gdb.SQLDialect(), statement)))
+
+	rows, err := tx.Query(statement, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	query.Columns, err = resultColumns(rows, dataType)
+
+	return err
+}
+
+// resultColumns returns the result columns of the rows. The data types of the
+// columns are the type names of the driver mapped by dataType.
+func resultColumns(rows *sql.Rows, dataType func(column *sql.ColumnType) string) ([]Column, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]Column, 0, len(columnTypes))
+	for i, columnType := range columnTypes {
+		// Columns are nullable unless the driver knows better.
+		isNullable := "YES"
+		if nullable, ok := columnType.Nullable(); ok && !nullable {
+			isNullable = "NO"
+		}
+		columns = append(columns, Column{
+			OrdinalPosition: i + 1,
+			Name:            columnType.Name(),
+			DataType:        dataType(columnType),
+			IsNullable:      isNullable,
+			ScanType:        columnType.ScanType(),
+		})
+	}
+
+	return columns, nil
+}
+
+// limitClauseWords are the keywords starting the clauses of a SELECT
+// statement which limit or lock its rows.
+var limitClauseWords = map[string]bool{
+	"LIMIT":  true,
+	"OFFSET": true,
+	"FETCH":  true,
+	"FOR":    true,
+}
+
+// sqlWords calls fn with the position, the parenthesis depth and the upper
+// cased keyword or identifier of every word of the query outside of string
+// literals, quoted identifiers and comments. It returns the end of the last
+// token of the query, which excludes trailing comments and semicolons.
+func sqlWords(query string, fn func(pos, depth int, word string) bool) (end int) {
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			if n := strings.IndexByte(query[i+1:], c); n >= 0 {
+				i += n + 1
+			} else {
+				i = len(query) - 1
+			}
+			end = i + 1
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			if n := strings.IndexByte(query[i:], '\n'); n >= 0 {
+				i += n
+			} else {
+				i = len(query)
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			if n := strings.Index(query[i:], "*/"); n >= 0 {
+				i += n + 1
+			} else {
+				i = len(query)
+			}
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			j := i + 1
+			for j < len(query) && (query[j] == '_' || query[j] == '$' ||
+				'a' <= query[j] && query[j] <= 'z' || 'A' <= query[j] && query[j] <= 'Z' || '0' <= query[j] && query[j] <= '9') {
+				j++
+			}
+			if !fn(i, depth, strings.ToUpper(query[i:j])) {
+				return i
+			}
+			i, end = j-1, j
+		case c == ';' || c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		default:
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			}
+			end = i + 1
+		}
+	}
+	return end
+}
+
+// isSelect returns true if the query is a SELECT statement, which may start
+// with a WITH clause.
+func isSelect(query string) bool {
+	var first string
+	sqlWords(query, func(_, _ int, word string) bool {
+		first = word
+		return false
+	})
+	return first == "SELECT" || first == "WITH" || first == "VALUES" || first == "TABLE"
+}
+
+// trimStatement returns the statement without trailing comments and
+// semicolons, which some drivers take as a statement of its own.
+func trimStatement(query string) string {
+	return query[:sqlWords(query, func(int, int, string) bool { return true })]
+}
+
+// limitZero returns the SELECT statement limited to no rows in the syntax of
+// the database. Its own limit and locking clauses get cut off, as well as
+// trailing comments. MSSQL has no LIMIT, so the statement gets wrapped in a
+// subselect without rows, which must not be ordered.
+func limitZero(dbType settings.DBType, query string) string {
+	isMsSQL := dbType == settings.DBTypeMsSQL
+	end := sqlWords(query, func(_, depth int, word string) bool {
+		return depth > 0 || !limitClauseWords[word] && !(isMsSQL && word == "ORDER")
+	})
+	query = strings.TrimRight(query[:end], " \t\r\n")
+	if isMsSQL {
+		return "SELECT * FROM (" + query + ") AS tables_to_go_describe WHERE 1=0"
+	}
+	return query + " LIMIT 0"
+}
+
+// driverDataType returns the lower-cased type name of the driver, which
+// matches the data types of most databases.
+func driverDataType(column *sql.ColumnType) string {
+	return strings.ToLower(column.DatabaseTypeName())
+}
+
+// QueryTable returns the table name with the result columns of the SELECT
+// statement, which gets described like the queries of SQL files without being
+// executed.
+func QueryTable(db Database, name, query string) (*Table, error) {
+	q := &Query{
+		Name: name,
+		Kind: QueryKindMany,
+		SQL:  trimStatement(strings.TrimSpace(query)),
+	}
+	if err := DescribeQuery(db, q); err != nil {
+		return nil, err
+	}
+	return &Table{Name: name, Columns: q.Columns}, nil
+}
@@ -0,0 +1,446 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestParseQueries(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		expected []*Query
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc: "queries get split by their annotations",
+			content: `-- users.sql
+-- name: GetUserByEmail :one
+SELECT id, email
+FROM users
+WHERE email = $1;
+
+-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1;
+`,
+			expected: []*Query{
+				{
+					Name: "GetUserByEmail",
+					Kind: QueryKindOne,
+					SQL:  "SELECT id, email\nFROM users\nWHERE email = $1",
+				},
+				{
+					Name: "DeleteUser",
+					Kind: QueryKindExecRows,
+					SQL:  "DELETE FROM users WHERE id = $1",
+				},
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc:     "unknown kind produces error",
+			content:  "-- name: ListUsers :all\nSELECT * FROM users",
+			expected: nil,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "statement without annotation produces error",
+			content:  "SELECT * FROM users",
+			expected: nil,
+			isError:  assert.Error,
+		},
+		{
+			desc:     "annotation without statement produces error",
+			content:  "-- name: ListUsers :many\n\n-- name: DeleteUsers :exec\nDELETE FROM users",
+			expected: nil,
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, err := ParseQueries(test.content)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestQueryParams(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		query    string
+		expected []Column
+	}{
+		{
+			desc:   "pg parameters get named by the compared columns",
+			dbType: settings.DBTypePostgresql,
+			query:  `SELECT * FROM users WHERE u."email" = $2 AND id IN ($1, $3) AND name LIKE $2 AND note = '$4' -- $5`,
+			expected: []Column{
+				{OrdinalPosition: 1, Name: "id"},
+				{OrdinalPosition: 2, Name: "email"},
+				{OrdinalPosition: 3},
+			},
+		},
+		{
+			desc:   "mysql parameters in order",
+			dbType: settings.DBTypeMySQL,
+			query:  "UPDATE users SET `name` = ? WHERE id >= ? /* ? */",
+			expected: []Column{
+				{OrdinalPosition: 1, Name: "name"},
+				{OrdinalPosition: 2, Name: "id"},
+			},
+		},
+		{
+			desc:   "mssql numbered parameters",
+			dbType: settings.DBTypeMsSQL,
+			query:  "SELECT * FROM users WHERE [id] = @p1 OR @p2 IS NULL",
+			expected: []Column{
+				{OrdinalPosition: 1, Name: "id"},
+				{OrdinalPosition: 2},
+			},
+		},
+		{
+			desc:   "insert values get named by the columns",
+			dbType: settings.DBTypeSQLite,
+			query:  "INSERT INTO users (email, \"name\", created_at) VALUES (?, ?, CURRENT_TIMESTAMP) RETURNING id",
+			expected: []Column{
+				{OrdinalPosition: 1, Name: "email"},
+				{OrdinalPosition: 2, Name: "name"},
+			},
+		},
+		{
+			desc:   "pg insert values get named by the columns",
+			dbType: settings.DBTypePostgresql,
+			query:  "INSERT INTO users (id, email) VALUES ($2, $1)",
+			expected: []Column{
+				{OrdinalPosition: 1, Name: "email"},
+				{OrdinalPosition: 2, Name: "id"},
+			},
+		},
+		{
+			desc:     "without parameters",
+			dbType:   settings.DBTypeSQLite,
+			query:    "SELECT * FROM users WHERE name = '?'",
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := queryParams(test.dbType, test.query)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestIsSelect(t *testing.T) {
+	tests := []struct {
+		desc     string
+		query    string
+		expected bool
+	}{
+		{
+			desc:     "select",
+			query:    "SELECT id FROM users",
+			expected: true,
+		},
+		{
+			desc:     "select after comments and parentheses",
+			query:    "-- all users\n/* ids */ (select id FROM users)",
+			expected: true,
+		},
+		{
+			desc:     "common table expression",
+			query:    "WITH u AS (SELECT id FROM users) SELECT id FROM u",
+			expected: true,
+		},
+		{
+			desc:     "insert returning",
+			query:    "INSERT INTO users (email) VALUES (?) RETURNING id",
+			expected: false,
+		},
+		{
+			desc:     "delete",
+			query:    "DELETE FROM users",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := isSelect(test.query)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestTrimStatement(t *testing.T) {
+	tests := []struct {
+		desc     string
+		query    string
+		expected string
+	}{
+		{
+			desc:     "statement stays as is",
+			query:    "SELECT id FROM users WHERE email = '--;'",
+			expected: "SELECT id FROM users WHERE email = '--;'",
+		},
+		{
+			desc:     "trailing semicolon and comments get cut off",
+			query:    "SELECT id -- the id\nFROM users; -- all users\n/* done */",
+			expected: "SELECT id -- the id\nFROM users",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := trimStatement(test.query)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestLimitZero(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		query    string
+		expected string
+	}{
+		{
+			desc:     "statement gets limited",
+			dbType:   settings.DBTypeMySQL,
+			query:    "SELECT id, id FROM users ORDER BY id",
+			expected: "SELECT id, id FROM users ORDER BY id LIMIT 0",
+		},
+		{
+			desc:     "own limit gets replaced",
+			dbType:   settings.DBTypeMySQL,
+			query:    "SELECT id FROM users ORDER BY id LIMIT ? OFFSET ?",
+			expected: "SELECT id FROM users ORDER BY id LIMIT 0",
+		},
+		{
+			desc:     "locking clause gets cut off",
+			dbType:   settings.DBTypeMySQL,
+			query:    "SELECT id FROM users WHERE id = ? FOR UPDATE",
+			expected: "SELECT id FROM users WHERE id = ? LIMIT 0",
+		},
+		{
+			desc:     "limit of a subquery is kept",
+			dbType:   settings.DBTypeMySQL,
+			query:    "SELECT id FROM (SELECT id FROM users LIMIT 10) AS u",
+			expected: "SELECT id FROM (SELECT id FROM users LIMIT 10) AS u LIMIT 0",
+		},
+		{
+			desc:     "keywords in literals and identifiers are ignored",
+			dbType:   settings.DBTypeMySQL,
+			query:    "SELECT 'limit' AS `for`, unlimited FROM users",
+			expected: "SELECT 'limit' AS `for`, unlimited FROM users LIMIT 0",
+		},
+		{
+			desc:     "trailing comments and semicolons get cut off",
+			dbType:   settings.DBTypeMySQL,
+			query:    "SELECT id FROM users; -- all users",
+			expected: "SELECT id FROM users LIMIT 0",
+		},
+		{
+			desc:     "mssql statement gets wrapped in a subselect without rows",
+			dbType:   settings.DBTypeMsSQL,
+			query:    "SELECT id FROM users WHERE id > @p1",
+			expected: "SELECT * FROM (SELECT id FROM users WHERE id > @p1) AS tables_to_go_describe WHERE 1=0",
+		},
+		{
+			desc:     "mssql ordering and paging get cut off",
+			dbType:   settings.DBTypeMsSQL,
+			query:    "SELECT id FROM users ORDER BY id OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY;",
+			expected: "SELECT * FROM (SELECT id FROM users) AS tables_to_go_describe WHERE 1=0",
+		},
+		{
+			desc:     "mssql ordering of a window is kept",
+			dbType:   settings.DBTypeMsSQL,
+			query:    "SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS n FROM users",
+			expected: "SELECT * FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS n FROM users) AS tables_to_go_describe WHERE 1=0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := limitZero(test.dbType, test.query)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+// describeConn is a connection returning result columns without rows. Like
+// real drivers, its statements expect an argument per placeholder.
+type describeConn struct {
+	columns []string
+	queries []string
+}
+
+func (c *describeConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *describeConn) Driver() driver.Driver                        { return nil }
+func (c *describeConn) Close() error                                 { return nil }
+func (c *describeConn) Begin() (driver.Tx, error)                    { return describeTx{}, nil }
+
+func (c *describeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return describeTx{}, nil
+}
+
+func (c *describeConn) Prepare(query string) (driver.Stmt, error) {
+	c.queries = append(c.queries, query)
+	return &describeStmt{conn: c, query: query}, nil
+}
+
+type describeTx struct{}
+
+func (describeTx) Commit() error   { return nil }
+func (describeTx) Rollback() error { return nil }
+
+type describeStmt struct {
+	conn  *describeConn
+	query string
+}
+
+func (s *describeStmt) Close() error { return nil }
+
+func (s *describeStmt) NumInput() int {
+	return strings.Count(s.query, "?") + strings.Count(s.query, "@p")
+}
+
+func (s *describeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("statement must not be executed")
+}
+
+func (s *describeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &describeRows{columns: s.conn.columns}, nil
+}
+
+type describeRows struct {
+	columns []string
+}
+
+func (r *describeRows) Columns() []string         { return r.columns }
+func (r *describeRows) Close() error              { return nil }
+func (r *describeRows) Next([]driver.Value) error { return io.EOF }
+
+func TestDescribeQuery(t *testing.T) {
+	tests := []struct {
+		desc      string
+		dbType    settings.DBType
+		dialect   settings.Dialect
+		query     string
+		statement string
+	}{
+		{
+			desc:      "mysql query with limit and offset",
+			dbType:    settings.DBTypeMySQL,
+			query:     "SELECT id, email FROM users WHERE email LIKE ? ORDER BY id LIMIT ? OFFSET ?",
+			statement: "SELECT id, email FROM users WHERE email LIKE ? ORDER BY id LIMIT 0",
+		},
+		{
+			desc:      "generic query with limit and offset",
+			dbType:    settings.DBTypeGeneric,
+			dialect:   "sqlite3",
+			query:     "SELECT id, email FROM users ORDER BY id LIMIT ? OFFSET ?",
+			statement: "SELECT id, email FROM users ORDER BY id LIMIT 0",
+		},
+		{
+			desc:      "generic query with paging of mssql",
+			dbType:    settings.DBTypeGeneric,
+			dialect:   "mssql",
+			query:     "SELECT id, email FROM users WHERE id > @p1 ORDER BY id OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY",
+			statement: "SELECT * FROM (SELECT id, email FROM users WHERE id > @p1) AS tables_to_go_describe WHERE 1=0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			s.Dialect = test.dialect
+
+			conn := &describeConn{columns: []string{"id", "email"}}
+			db := New(s)
+			switch db := db.(type) {
+			case *MySQL:
+				db.DB = sqlx.NewDb(sql.OpenDB(conn), "mysql")
+			case *Generic:
+				db.DB = sqlx.NewDb(sql.OpenDB(conn), "describe")
+			}
+
+			query := &Query{Name: "ListUsers", Kind: QueryKindMany, SQL: test.query}
+			err := DescribeQuery(db, query)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{test.statement}, conn.queries)
+			assert.Equal(t, []string{"id", "email"}, []string{query.Columns[0].Name, query.Columns[1].Name})
+		})
+	}
+}
+
+// queryDatabase records the described query.
+type queryDatabase struct {
+	Database
+	query   *Query
+	columns []Column
+}
+
+func (db *queryDatabase) DescribeQuery(query *Query) error {
+	db.query = query
+	query.Columns = db.columns
+	return nil
+}
+
+func TestQueryTable(t *testing.T) {
+	tests := []struct {
+		desc     string
+		query    string
+		columns  []Column
+		expected string
+	}{
+		{
+			desc:     "trailing semicolon gets cut off",
+			query:    "SELECT 1 AS one;\n",
+			columns:  []Column{{OrdinalPosition: 1, Name: "one"}},
+			expected: "SELECT 1 AS one",
+		},
+		{
+			desc:  "duplicate column names are kept",
+			query: "SELECT u.id, o.id FROM users AS u JOIN orders AS o ON o.user_id = u.id",
+			columns: []Column{
+				{OrdinalPosition: 1, Name: "id"},
+				{OrdinalPosition: 2, Name: "id"},
+			},
+			expected: "SELECT u.id, o.id FROM users AS u JOIN orders AS o ON o.user_id = u.id",
+		},
+		{
+			desc:     "order by is described as is",
+			query:    "SELECT email FROM users ORDER BY email",
+			columns:  []Column{{OrdinalPosition: 1, Name: "email"}},
+			expected: "SELECT email FROM users ORDER BY email",
+		},
+		{
+			desc:     "trailing comment gets cut off",
+			query:    "SELECT email FROM users -- all users",
+			columns:  []Column{{OrdinalPosition: 1, Name: "email"}},
+			expected: "SELECT email FROM users",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			db := &queryDatabase{columns: test.columns}
+
+			actual, err := QueryTable(db, "Report", test.query)
+			assert.NoError(t, err)
+			assert.Equal(t, &Table{Name: "Report", Columns: test.columns}, actual)
+			assert.Equal(t, test.expected, db.query.SQL)
+			assert.Equal(t, QueryKindMany, db.query.Kind)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Factory creates the Database for the given settings.
+type Factory func(s *settings.Settings) Database
+
+var (
+	// factories maps the registered database types to their factories.
+	factories = map[settings.DBType]Factory{}
+	// factoriesMu guards factories against concurrent registrations.
+	factoriesMu sync.RWMutex
+)
+
+// Register makes a database type available under the given name, which can
+// then be chosen with the flag -t. The default port is used if none is given
+// in the settings. It is meant to be called from init functions and panics
+// if the factory is nil or the name is already registered, the same way
+// database/sql handles drivers.
+func Register(name settings.DBType, factory Factory, defaultPort string) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic("database: Register factory is nil")
+	}
+	if _, dup := factories[name]; dup {
+		panic(fmt.Sprintf("database: Register called twice for %q", name))
+	}
+
+	factories[name] = factory
+	settings.RegisterDbType(name, defaultPort)
+}
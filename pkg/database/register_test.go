@@ -0,0 +1,81 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		expected Database
+	}{
+		{
+			desc:     "postgresql",
+			dbType:   settings.DBTypePostgresql,
+			expected: &Postgresql{},
+		},
+		{
+			desc:     "mysql",
+			dbType:   settings.DBTypeMySQL,
+			expected: &MySQL{},
+		},
+		{
+			desc:     "sqlite",
+			dbType:   settings.DBTypeSQLite,
+			expected: &SQLite{},
+		},
+		{
+			desc:     "mssql",
+			dbType:   settings.DBTypeMsSQL,
+			expected: &MsSQL{},
+		},
+		{
+			desc:     "generic",
+			dbType:   settings.DBTypeGeneric,
+			expected: &Generic{},
+		},
+		{
+			desc:     "unknown type falls back to postgresql",
+			dbType:   "unknown",
+			expected: &Postgresql{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			assert.IsType(t, test.expected, New(s))
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	const name settings.DBType = "test"
+	defer func() {
+		delete(factories, name)
+	}()
+
+	Register(name, func(s *settings.Settings) Database {
+		return NewGeneric(s)
+	}, "1234")
+
+	s := settings.New()
+	assert.NoError(t, s.DbType.Set(string(name)))
+	assert.NoError(t, s.Verify())
+	assert.Equal(t, "1234", s.Port)
+	assert.IsType(t, &Generic{}, New(s))
+
+	assert.Panics(t, func() {
+		Register(name, func(s *settings.Settings) Database {
+			return NewGeneric(s)
+		}, "")
+	})
+	assert.Panics(t, func() {
+		Register("nil", nil, "")
+	})
+}
@@ -2,7 +2,6 @@ package database
 
 import (
 	"database/sql"
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -29,13 +28,16 @@ func (s *SQLite) GetColumnsOfView(table *Table) (err error) {
 	panic("implement me")
 }
 
+func init() {
+	Register(settings.DBTypeSQLite, func(s *settings.Settings) Database {
+		return NewSQLite(s)
+	}, "")
+}
+
 // NewSQLite creates a new SQLite database.
 func NewSQLite(s *settings.Settings) *SQLite {
 	return &SQLite{
-		GeneralDatabase: &GeneralDatabase{
-			Settings: s,
-			driver:   dbTypeToDriverMap[s.DbType],
-		},
+		GeneralDatabase: NewGeneralDatabase(s, "sqlite3"),
 	}
 }
 
@@ -74,11 +76,8 @@ func (s *SQLite) GetTables() (tables []*Table, err error) {
 		AND name NOT LIKE 'sqlite?_%' escape '?'
 	`)
 
-	if s.Verbose {
-		if err != nil {
-			fmt.Println("> Error at GetTables()")
-			fmt.Printf("> database: %q\r\n", s.DbName)
-		}
+	if err != nil {
+		s.Log().Debug("could not get tables", "database", s.DbName, "error", err)
 	}
 
 	return tables, err
@@ -95,10 +94,7 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 		FROM PRAGMA_TABLE_INFO('` + table.Name + `')
 	`)
 	if err != nil {
-		if s.Verbose {
-			fmt.Printf("> Error at GetColumnsOfTable(%v)\r\n", table.Name)
-			fmt.Printf("> database: %q\r\n", s.DbName)
-		}
+		s.Log().Debug("could not get columns of table", "table", table.Name, "database", s.DbName, "error", err)
 		return err
 	}
 
@@ -123,8 +119,9 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 			isNullable = "NO"
 		}
 
+		// pk is the position of the column in the primary key
 		isPrimaryKey := ""
-		if col.PrimaryKey == 1 {
+		if col.PrimaryKey > 0 {
 			isPrimaryKey = "PK"
 		}
 
@@ -136,6 +133,10 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 			IsNullable:             isNullable,
 			CharacterMaximumLength: sql.NullInt64{},
 			NumericPrecision:       sql.NullInt64{},
+			NumericScale:           sql.NullInt64{},
+			EnumValues:             sql.NullString{},
+			Comment:                sql.NullString{},
+			IsGenerated:            "",
 			// reuse mysql column_key as primary key indicator
 			ColumnKey:      isPrimaryKey,
 			Extra:          "",
@@ -144,47 +145,112 @@ func (s *SQLite) GetColumnsOfTable(table *Table) (err error) {
 		})
 	}
 
+	markRowID(table.Columns)
+
 	return nil
 }
 
+// markRowID marks the primary key as auto increment if it is an alias of the
+// rowid, which is only the case for a single column of the type INTEGER.
+func markRowID(columns []Column) {
+	key := -1
+	for i, column := range columns {
+		if column.ColumnKey != "PK" {
+			continue
+		}
+		if key >= 0 {
+			return
+		}
+		key = i
+	}
+	if key >= 0 && strings.EqualFold(columns[key].DataType, "INTEGER") {
+		columns[key].Extra = "auto_increment"
+	}
+}
+
+// DescribeQuery describes the parameters and result columns of the query. The
+// statement only gets prepared, as the driver describes the result columns
+// before the first step executing it.
+func (s *SQLite) DescribeQuery(query *Query) (err error) {
+	query.Params = queryParams(s.DbType, query.SQL)
+
+	if !query.Kind.HasRows() {
+		return nil
+	}
+
+	rows, err := s.DB.Query(trimStatement(query.SQL), make([]any, len(query.Params))...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	query.Columns, err = resultColumns(rows, driverDataType)
+
+	return err
+}
+
 func (s *SQLite) IsPrimaryKey(column Column) bool {
 	return column.ColumnKey == "PK"
 }
 
+// IsAutoIncrement checks if the column is the alias of the rowid, see
+// markRowID.
 func (s *SQLite) IsAutoIncrement(column Column) bool {
-	return column.ColumnKey == "PK"
+	return column.Extra == "auto_increment"
+}
+
+func (s *SQLite) IsUnique(_ Column) bool {
+	return false
+}
+
+func (s *SQLite) IsGenerated(_ Column) bool {
+	return false
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) GetStringDatatypes() []string {
 	return []string{
 		"text",
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) IsString(column Column) bool {
 	return isStringInSlice(column.DataType, s.GetStringDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) GetTextDatatypes() []string {
 	return []string{
 		"text",
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) IsText(column Column) bool {
 	return isStringInSlice(column.DataType, s.GetTextDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) GetIntegerDatatypes() []string {
 	return []string{
 		"integer",
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) IsInteger(column Column) bool {
 	return isStringInSlice(column.DataType, s.GetIntegerDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) GetFloatDatatypes() []string {
 	return []string{
 		"real",
@@ -192,14 +258,20 @@ func (s *SQLite) GetFloatDatatypes() []string {
 	}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) IsFloat(column Column) bool {
 	return isStringInSlice(column.DataType, s.GetFloatDatatypes())
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) GetTemporalDatatypes() []string {
 	return []string{}
 }
 
+// Deprecated: Data types are classified by the typemapper package, see
+// typemapper.DialectOf.
 func (s *SQLite) IsTemporal(_ Column) bool {
 	return false
 }
@@ -138,3 +138,54 @@ func TestSQLite_DSN(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkRowID(t *testing.T) {
+	tests := []struct {
+		desc     string
+		columns  []Column
+		expected []bool
+	}{
+		{
+			desc: "single integer primary key is the rowid",
+			columns: []Column{
+				{Name: "id", DataType: "INTEGER", ColumnKey: "PK"},
+				{Name: "email", DataType: "TEXT"},
+			},
+			expected: []bool{true, false},
+		},
+		{
+			desc: "text primary key is no rowid",
+			columns: []Column{
+				{Name: "token", DataType: "TEXT", ColumnKey: "PK"},
+			},
+			expected: []bool{false},
+		},
+		{
+			desc: "bigint primary key is no rowid",
+			columns: []Column{
+				{Name: "id", DataType: "BIGINT", ColumnKey: "PK"},
+			},
+			expected: []bool{false},
+		},
+		{
+			desc: "composite primary key is no rowid",
+			columns: []Column{
+				{Name: "order_id", DataType: "INTEGER", ColumnKey: "PK"},
+				{Name: "item_id", DataType: "INTEGER", ColumnKey: "PK"},
+			},
+			expected: []bool{false, false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			markRowID(test.columns)
+
+			db := NewSQLite(settings.New())
+			actual := make([]bool, len(test.columns))
+			for i, column := range test.columns {
+				actual[i] = db.IsAutoIncrement(column)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
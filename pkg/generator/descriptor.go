@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/runtime"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// runtimeImport is the import path of the package of the runtime.Repository.
+const runtimeImport = "github.com/fraenky8/tables-to-go/pkg/runtime"
+
+// dialectNames are the Go names of the runtime dialects per database type.
+var dialectNames = map[settings.DBType]string{
+	settings.DBTypePostgresql: "DialectPostgresql",
+	settings.DBTypeMySQL:      "DialectMySQL",
+	settings.DBTypeSQLite:     "DialectSQLite",
+	settings.DBTypeMsSQL:      "DialectMsSQL",
+}
+
+// descriptor is the model of the generated runtime.Descriptor of a table.
+type descriptor struct {
+	// Name is the Go name of the variable holding the descriptor.
+	Name string
+	// Dialect is the Go name of the runtime dialect of the database.
+	Dialect string
+
+	Columns       []string
+	PrimaryKey    []string
+	AutoIncrement []string
+	Generated     []string
+}
+
+// newDescriptor creates the model of the descriptor of the table of the given
+// file.
+func newDescriptor(s *settings.Settings, db database.Database, file structFile) *descriptor {
+	d := &descriptor{
+		Name:    file.StructName + "Descriptor",
+		Dialect: dialectNames[s.SQLDialect()],
+	}
+
+	for _, field := range file.Fields {
+		d.Columns = append(d.Columns, field.Column.Name)
+		if field.IsPrimaryKey {
+			d.PrimaryKey = append(d.PrimaryKey, field.Column.Name)
+		}
+		if field.IsAutoIncrement {
+			d.AutoIncrement = append(d.AutoIncrement, field.Column.Name)
+		}
+		if database.IsGenerated(db, field.Column) {
+			d.Generated = append(d.Generated, field.Column.Name)
+		}
+	}
+
+	return d
+}
+
+// dialect returns the runtime dialect of the database of the settings.
+func dialect(s *settings.Settings) runtime.Dialect {
+	return runtime.Dialect(s.SQLDialect())
+}
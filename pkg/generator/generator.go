@@ -0,0 +1,922 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"github.com/jinzhu/inflection"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/tagger"
+	"github.com/fraenky8/tables-to-go/pkg/typemapper"
+)
+
+// levelTrace is the log level of the details logged with VVerbose.
+const levelTrace = settings.LevelTrace
+
+var (
+	// commonInitialisms are the strings for idiomatic go in column names,
+	// taken from golint. Additional ones can be given by the settings.
+	// see https://github.com/golang/go/wiki/CodeReviewComments#initialisms
+	commonInitialisms = map[string]bool{
+		"ACL":   true,
+		"API":   true,
+		"ASCII": true,
+		"CPU":   true,
+		"CSS":   true,
+		"DNS":   true,
+		"EOF":   true,
+		"GUID":  true,
+		"HTML":  true,
+		"HTTP":  true,
+		"HTTPS": true,
+		"ID":    true,
+		"IP":    true,
+		"JSON":  true,
+		"LHS":   true,
+		"QPS":   true,
+		"RAM":   true,
+		"RHS":   true,
+		"RPC":   true,
+		"SLA":   true,
+		"SMTP":  true,
+		"SQL":   true,
+		"SSH":   true,
+		"TCP":   true,
+		"TLS":   true,
+		"TTL":   true,
+		"UDP":   true,
+		"UI":    true,
+		"UID":   true,
+		"UUID":  true,
+		"URI":   true,
+		"URL":   true,
+		"UTF8":  true,
+		"VM":    true,
+		"XML":   true,
+		"XMPP":  true,
+		"XSRF":  true,
+		"XSS":   true,
+	}
+)
+
+// Options are the options of a generation.
+type Options struct {
+	// Settings are the settings to generate with. They should be verified by
+	// settings.Verify beforehand.
+	Settings *settings.Settings
+	// Database is the connected database to read the tables, views and
+	// queries from.
+	Database database.Database
+	// Tables are the tables with their columns to generate the structs for
+	// instead of reading them from a database. Keys and data types of the
+	// columns are interpreted by the database type of the settings. Queries
+	// can't be generated without a database.
+	Tables []*database.Table
+	// Writer writes the generated files.
+	Writer output.Writer
+	// TypeMapper maps the columns to Go types. If nil, the default type
+	// mapper of the database type of the settings is used.
+	TypeMapper typemapper.TypeMapper
+}
+
+// Result is the outcome of a generation.
+type Result struct {
+	// Files are the names of the written files as given to the writer, eg.
+	// `Users`.
+	Files []string
+	// Skipped are the tables, views, queries and SQL files which were skipped
+	// because of an error, which only happens with Force.
+	Skipped []Skipped
+	// Tables are the outcomes of the tables and views in their order.
+	Tables []*TableResult
+	// Warnings are the warnings of the run not belonging to a table or view,
+	// eg. renames matching no table, view or column.
+	Warnings []string
+}
+
+// These are the statuses of a TableResult.
+const (
+	StatusGenerated = "generated"
+	StatusSkipped   = "skipped"
+	StatusFailed    = "failed"
+)
+
+// TableResult is the outcome of a table, view, query or SQL file.
+type TableResult struct {
+	// Kind is either "table", "view", "query" or "file".
+	Kind string
+	// Name is the name of the table, view or query or the path of the file.
+	Name string
+	// Status is either StatusGenerated, StatusSkipped with Force or
+	// StatusFailed.
+	Status string
+	// Columns is the number of fields of the generated struct, 0 if the
+	// columns couldn't be read and for SQL files.
+	Columns int
+	// File is the name of the written file as given to the writer.
+	File string
+	// Warnings are the columns of unmapped data types falling back to
+	// strings, the renamed identifiers and the skipped queries of a file.
+	Warnings []string
+	// Error is the error of a skipped or failed table, view, query or file.
+	Error string
+}
+
+// generator holds the state of a single run of Generate.
+type generator struct {
+	taggers        *tagger.Taggers
+	typeMapper     typemapper.TypeMapper
+	structTemplate *template.Template
+	logger         *slog.Logger
+	result         *Result
+	// current is the table or view being generated, nil in between.
+	current *TableResult
+}
+
+// newGenerator creates the generator of a run with the settings. If the type
+// mapper is nil, the default one of the database type is used.
+func newGenerator(s *settings.Settings, typeMapper typemapper.TypeMapper) *generator {
+	if typeMapper == nil {
+		typeMapper = typemapper.Default(s.DbType, s.Null)
+	}
+	return &generator{
+		typeMapper: typeMapper,
+		logger:     s.Log(),
+		result:     &Result{},
+	}
+}
+
+// Skipped is a table, view, query or SQL file which was skipped.
+type Skipped struct {
+	// Kind is either "table", "view", "query" or "file".
+	Kind string
+	// Name is the name of the table, view or query or the path of the file.
+	Name string
+	// Reason is the error causing the skip.
+	Reason string
+}
+
+// Generate generates the files of the tables and views of the database, or
+// of the queries of the settings, and writes them by the writer of the
+// options. The result is returned even on error, holding the files written
+// so far. The diagnostics go to the logger of the settings.
+func Generate(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Settings == nil {
+		return nil, errors.New("no settings given")
+	}
+	if opts.Writer == nil {
+		return nil, errors.New("no writer given")
+	}
+
+	db := opts.Database
+	switch {
+	case db != nil && opts.Tables != nil:
+		return nil, errors.New("either a database or tables can be given")
+	case opts.Tables != nil:
+		db = newSchema(opts.Settings, opts.Tables)
+	case db == nil:
+		return nil, errors.New("neither a database nor tables given")
+	}
+
+	g := newGenerator(opts.Settings, opts.TypeMapper)
+
+	err := g.run(ctx, opts.Settings, db, opts.Writer)
+	if err != nil && g.current != nil && g.current.Status == StatusFailed {
+		g.current.Error = err.Error()
+	}
+
+	return g.result, err
+}
+
+// skip logs the error of the table, view, query or file and records it as
+// skipped.
+func (g *generator) skip(kind, name string, err error) {
+	g.logger.Warn("skipping "+kind, kind, name, "error", err)
+	g.result.Skipped = append(g.result.Skipped, Skipped{Kind: kind, Name: name, Reason: err.Error()})
+
+	if g.current == nil {
+		return
+	}
+	switch {
+	case g.current.Kind == kind && g.current.Name == name:
+		g.current.Status = StatusSkipped
+		g.current.Error = err.Error()
+	case g.current.Kind == "file" && kind == "query":
+		g.warn("skipping query %q: %v", name, err)
+	}
+}
+
+// begin records the table, view, query or file as the current one, which the
+// following warnings and the written file belong to.
+func (g *generator) begin(kind, name string) {
+	g.current = &TableResult{Kind: kind, Name: name, Status: StatusFailed}
+	g.result.Tables = append(g.result.Tables, g.current)
+}
+
+// warn records the warning for the current table, view, query or file, if any.
+func (g *generator) warn(format string, args ...any) {
+	if g.current == nil {
+		return
+	}
+	warning := fmt.Sprintf(format, args...)
+	for _, w := range g.current.Warnings {
+		if w == warning {
+			return
+		}
+	}
+	g.current.Warnings = append(g.current.Warnings, warning)
+}
+
+// write writes the file by the writer and records it as written.
+func (g *generator) write(out output.Writer, fileName, content string) error {
+	if err := out.Write(fileName, content); err != nil {
+		return err
+	}
+	g.result.Files = append(g.result.Files, fileName)
+
+	if g.current != nil {
+		g.current.Status = StatusGenerated
+		g.current.File = fileName
+	}
+	return nil
+}
+
+// run runs the transformations of the tables, views or queries.
+func (g *generator) run(ctx context.Context, settings *settings.Settings, db database.Database, out output.Writer) (err error) {
+
+	g.taggers, err = tagger.NewTaggersWithTemplates(settings)
+	if err != nil {
+		return fmt.Errorf("could not create taggers: %w", err)
+	}
+	g.taggers.SetTypeMapper(g.typeMapper)
+
+	// The generated queries and the runtime package need the SQL dialect.
+	if (settings.GenerateDescriptor || settings.GenerateTypedColumns || settings.GenerateRepository || settings.GenerateUpsert) &&
+		dialectNames[settings.SQLDialect()] == "" {
+		return fmt.Errorf("database type %q has no SQL dialect for the descriptors, typed columns, repository and upsert, see -dialect", settings.DbType)
+	}
+
+	g.structTemplate, err = newStructTemplate(settings)
+	if err != nil {
+		return err
+	}
+
+	// struct and file names must be unique across tables and views
+	structNames, fileNames := identifiers{}, identifiers{}
+
+	g.logger.Info("running", "db_type", settings.DbType)
+
+	if len(settings.SQLFiles) > 0 {
+		if err = g.generateQueries(ctx, settings, db, out); err != nil {
+			return err
+		}
+		g.logger.Info("done")
+		return nil
+	}
+
+	if settings.Query != "" {
+		if err = g.generateQueryStruct(settings, db, out, structNames, fileNames); err != nil {
+			return err
+		}
+		g.logger.Info("done")
+		return nil
+	}
+
+	tables, err := db.GetTables()
+	if err != nil {
+		return fmt.Errorf("could not get tables: %w", err)
+	}
+
+	g.logger.Debug("found tables", "count", len(tables))
+
+	if err = db.PrepareGetColumnsOfTableStmt(); err != nil {
+		return fmt.Errorf("could not prepare the get-column-statement: %w", err)
+	}
+
+	for _, table := range tables {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		g.logger.Debug("processing table", "table", table.Name)
+		g.begin("table", table.Name)
+
+		if err = db.GetColumnsOfTable(table); err != nil {
+			err = fmt.Errorf("could not get columns of table %q: %w", table.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("table", table.Name, err)
+			continue
+		}
+
+		g.logger.Debug("found columns", "table", table.Name, "count", len(table.Columns))
+
+		content, err := g.createTableStructString(settings, db, table, structNames)
+
+		if err != nil {
+			err = fmt.Errorf("could not create string for table %q: %w", table.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("table", table.Name, err)
+			continue
+		}
+
+		fileName, err := g.addIdentifier(fileNames, settings, formatFileName(settings, table), fmt.Sprintf("table %q", table.Name))
+		if err != nil {
+			err = fmt.Errorf("could not create file name for table %q: %w", table.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("table", table.Name, err)
+			continue
+		}
+
+		err = g.write(out, fileName, content)
+		if err != nil {
+			err = fmt.Errorf("could not write struct for table %q: %w", table.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("table", table.Name, err)
+		}
+	}
+
+	views, err := db.GetViews()
+	if err != nil {
+		return fmt.Errorf("could not get tables: %w", err)
+	}
+
+	g.logger.Debug("found views", "count", len(views))
+
+	if err = db.PrepareGetColumnsOfViewStmt(); err != nil {
+		return fmt.Errorf("could not prepare the get-column-statement: %w", err)
+	}
+
+	for _, view := range views {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		g.logger.Debug("processing view", "view", view.Name)
+		g.begin("view", view.Name)
+
+		if err = db.GetColumnsOfView(view); err != nil {
+			err = fmt.Errorf("could not get columns of view %q: %w", view.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("view", view.Name, err)
+			continue
+		}
+
+		g.logger.Debug("found columns", "view", view.Name, "count", len(view.Columns))
+
+		content, err := g.createTableStructString(settings, db, view, structNames)
+
+		if err != nil {
+			err = fmt.Errorf("could not create string for table %q: %w", view.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("view", view.Name, err)
+			continue
+		}
+
+		fileName, err := g.addIdentifier(fileNames, settings, formatFileName(settings, view), fmt.Sprintf("view %q", view.Name))
+		if err != nil {
+			err = fmt.Errorf("could not create file name for view %q: %w", view.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("view", view.Name, err)
+			continue
+		}
+
+		err = g.write(out, fileName, content)
+		if err != nil {
+			err = fmt.Errorf("could not write struct for table %q: %w", view.Name, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("view", view.Name, err)
+		}
+	}
+
+	g.current = nil
+
+	for _, rename := range unusedRenames(settings, append(tables, views...)) {
+		g.logger.Warn("rename does not match any table, view or column", "rename", rename)
+		g.result.Warnings = append(g.result.Warnings, fmt.Sprintf("rename %q does not match any table, view or column", rename))
+	}
+
+	g.logger.Info("done")
+
+	return nil
+}
+
+// generateQueryStruct generates the struct of the result columns of the query
+// of the settings, like the ones of the tables.
+func (g *generator) generateQueryStruct(settings *settings.Settings, db database.Database, out output.Writer, structNames, fileNames identifiers) error {
+
+	g.logger.Debug("processing query", "query", settings.QueryName)
+	g.begin("query", settings.QueryName)
+
+	table, err := database.QueryTable(db, settings.QueryName, settings.Query)
+	if err != nil {
+		return fmt.Errorf("could not get columns of query %q: %w", settings.QueryName, err)
+	}
+
+	g.logger.Debug("found columns", "query", settings.QueryName, "count", len(table.Columns))
+
+	content, err := g.createTableStructString(settings, db, table, structNames)
+	if err != nil {
+		return fmt.Errorf("could not create string for query %q: %w", table.Name, err)
+	}
+
+	fileName, err := g.addIdentifier(fileNames, settings, formatFileName(settings, table), fmt.Sprintf("query %q", table.Name))
+	if err != nil {
+		return fmt.Errorf("could not create file name for query %q: %w", table.Name, err)
+	}
+
+	if err = g.write(out, fileName, content); err != nil {
+		return fmt.Errorf("could not write struct for query %q: %w", table.Name, err)
+	}
+
+	return nil
+}
+
+// unusedRenames returns the sorted keys of the renames of the settings which
+// point at non-existent tables or columns.
+func unusedRenames(settings *settings.Settings, tables []*database.Table) []string {
+	existing := map[string]bool{}
+	for _, table := range tables {
+		existing[table.Name] = true
+		for _, column := range table.Columns {
+			existing[table.Name+"."+column.Name] = true
+		}
+	}
+
+	var unused []string
+	for key := range settings.Renames {
+		if !existing[key] {
+			unused = append(unused, key)
+		}
+	}
+	sort.Strings(unused)
+
+	return unused
+}
+
+// columnInfo collects the import paths of the Go types of the columns.
+type columnInfo struct {
+	imports []string
+}
+
+// add adds the import paths of the Go type.
+func (c *columnInfo) add(t typemapper.Type) {
+	for _, path := range t.Imports {
+		c.addImport(path)
+	}
+}
+
+// addImport adds the import path unless it was added before.
+func (c *columnInfo) addImport(path string) {
+	for _, imported := range c.imports {
+		if imported == path {
+			return
+		}
+	}
+	c.imports = append(c.imports, path)
+}
+
+func (g *generator) createTableStructString(settings *settings.Settings, db database.Database, table *database.Table, structNames identifiers) (string, error) {
+
+	if name, ok := settings.TableRename(table.Name); ok {
+		if name == "" || !validVariableName(name) || unicode.IsDigit(rune(name[0])) {
+			return "", fmt.Errorf("rename %q of table %q is not a valid Go identifier", name, table.Name)
+		}
+	}
+
+	tableName := formatTableName(settings, table.Name, settings.StructNameInflection)
+
+	// Check that the table name doesn't contain any invalid characters for Go variables
+	if !validVariableName(tableName) {
+		return "", fmt.Errorf("table name %q contains invalid characters", table.Name)
+	}
+
+	origin := fmt.Sprintf("table %q", table.Name)
+	tableName = g.escapeReserved(settings, tableName, origin, true)
+	tableName, err := g.addIdentifier(structNames, settings, tableName, origin)
+	if err != nil {
+		return "", err
+	}
+
+	file := structFile{
+		Package:      settings.PackageName,
+		Table:        table,
+		StructName:   tableName,
+		ReceiverName: receiverName(tableName),
+		Settings:     settings,
+	}
+
+	// The variables of the column names share the namespace of the structs.
+	if settings.GenerateColumns {
+		file.ColumnsName, err = g.addIdentifier(structNames, settings, tableName+"Columns",
+			fmt.Sprintf("column names of table %q", table.Name))
+		if err != nil {
+			return "", err
+		}
+		file.AllColumnsName, err = g.addIdentifier(structNames, settings, tableName+"AllColumns",
+			fmt.Sprintf("all column names of table %q", table.Name))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	columnInfo := columnInfo{}
+	type columnKey struct {
+		position int
+		name     string
+	}
+	columns := map[columnKey]struct{}{}
+	fieldNames := reservedFieldNames(settings)
+
+	for _, column := range table.Columns {
+		// ISSUE-4: if columns are part of multiple constraints
+		// then the sql returns multiple rows per column name.
+		// Therefore, we check if we already added a column with
+		// that name at that position to the struct, if so, skip.
+		// Result columns of queries with the same name are at
+		// different positions and collide by their field names.
+		key := columnKey{position: column.OrdinalPosition, name: column.Name}
+		if _, ok := columns[key]; ok {
+			continue
+		}
+		columns[key] = struct{}{}
+
+		columnName, err := g.formatColumnName(settings, column.Name, table.Name)
+		if err != nil {
+			return "", err
+		}
+
+		// Different columns can end up with the same field name,
+		// eg. `user_id` and `userId` both become `UserID`.
+		columnName, err = g.addIdentifier(fieldNames, settings, columnName,
+			fmt.Sprintf("column %q of table %q", column.Name, table.Name))
+		if err != nil {
+			return "", err
+		}
+
+		g.logger.Log(context.Background(), levelTrace, "processing column", "table", table.Name, "column", column.Name)
+
+		goType := g.mapColumnType(settings, db, column)
+		columnInfo.add(goType)
+		columnType := goType.Name
+
+		field := structField{
+			Name: columnName,
+			Type: columnType,
+			Tag: g.taggers.GenerateFieldTag(db, tagger.Field{
+				Table:    table,
+				Column:   column,
+				Name:     columnName,
+				Type:     columnType,
+				Settings: settings,
+			}),
+			Comment:         column.Comment.String,
+			Column:          column,
+			IsPrimaryKey:    db.IsPrimaryKey(column),
+			IsAutoIncrement: db.IsAutoIncrement(column),
+			IsNullable:      db.IsNullable(column),
+		}
+
+		file.Fields = append(file.Fields, field)
+		if field.IsPrimaryKey {
+			file.PrimaryKeys = append(file.PrimaryKeys, field)
+		}
+	}
+
+	// Columns of multiple constraints are only generated once.
+	if g.current != nil {
+		g.current.Columns = len(file.Fields)
+	}
+
+	// The functions of the repository share the namespace of the structs.
+	if settings.GenerateRepository {
+		file.Repository = newRepository(settings, db, file)
+		for _, name := range file.Repository.functionNames() {
+			*name, err = g.addIdentifier(structNames, settings, *name, fmt.Sprintf("function of table %q", table.Name))
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if settings.GenerateUpsert {
+		file.Upsert = newUpsert(settings, db, file)
+		if file.Upsert == nil {
+			g.logger.Debug("no upsert for table without primary key or unique column", "table", table.Name)
+		}
+	}
+	if file.Upsert != nil {
+		for _, name := range file.Upsert.names() {
+			*name, err = g.addIdentifier(structNames, settings, *name, fmt.Sprintf("upsert of table %q", table.Name))
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if settings.GenerateDescriptor {
+		file.Descriptor = newDescriptor(settings, db, file)
+		file.Descriptor.Name, err = g.addIdentifier(structNames, settings, file.Descriptor.Name,
+			fmt.Sprintf("descriptor of table %q", table.Name))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if settings.GenerateTypedColumns {
+		file.TypedColumns = g.newTypedColumns(settings, db, file)
+		file.TypedColumns.Name, err = g.addIdentifier(structNames, settings, file.TypedColumns.Name,
+			fmt.Sprintf("typed columns of table %q", table.Name))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	file.Imports, file.ExternalImports = generateImports(settings, columnInfo, file)
+
+	var fileContent strings.Builder
+	if err = g.structTemplate.Execute(&fileContent, file); err != nil {
+		return "", fmt.Errorf("could not execute template: %w", err)
+	}
+
+	return fileContent.String(), nil
+}
+
+// formatTableName transforms a table name with the given inflection mode
+// according to the provided settings. An explicit rename is taken as is.
+func formatTableName(settings *settings.Settings, table string, mode settings.Inflection) string {
+	if name, ok := settings.TableRename(table); ok {
+		return name
+	}
+	tableName := title(settings.Prefix + inflect(settings, table, mode) + settings.Suffix)
+	// Replace any whitespace with underscores
+	tableName = strings.Map(replaceSpace, tableName)
+	if settings.IsOutputFormatCamelCase() {
+		tableName = camelCaseString(tableName)
+	}
+	return tableName
+}
+
+// formatFileName returns the name of the file for the given table according
+// to the provided settings.
+func formatFileName(settings *settings.Settings, table *database.Table) string {
+	fileName := camelCaseString(formatTableName(settings, table.Name, settings.FileNameInflection))
+	if settings.IsFileNameFormatSnakeCase() {
+		fileName = strcase.ToSnake(fileName)
+	}
+	return fileName
+}
+
+// inflect applies the given inflection to the last word of name, eg. the
+// singular of `order_items` is `order_item`. The irregulars of the settings
+// take precedence over the English inflection rules.
+func inflect(s *settings.Settings, name string, mode settings.Inflection) string {
+	if mode == settings.InflectionNone || mode == "" {
+		return name
+	}
+
+	idx := strings.LastIndex(name, "_") + 1
+	words := splitCamelCase(name[idx:])
+	prefix, word := name[:idx]+strings.Join(words[:len(words)-1], ""), words[len(words)-1]
+
+	inflected := ""
+	for singular, plural := range s.Irregulars {
+		if mode == settings.InflectionSingular && strings.EqualFold(word, plural) {
+			inflected = singular
+		}
+		if mode == settings.InflectionPlural && strings.EqualFold(word, singular) {
+			inflected = plural
+		}
+	}
+
+	switch {
+	case inflected != "":
+		if unicode.IsUpper([]rune(word)[0]) {
+			inflected = title(inflected)
+		}
+	case mode == settings.InflectionSingular:
+		inflected = inflection.Singular(word)
+	default:
+		inflected = inflection.Plural(word)
+	}
+
+	return prefix + inflected
+}
+
+// generateImports returns the import paths needed by the file, separated
+// into the ones of the standard library and the Go types of the columns and
+// the external ones.
+func generateImports(settings *settings.Settings, columnInfo columnInfo, file structFile) (imports, externalImports []string) {
+
+	needsSqlx := file.Repository != nil || file.Upsert != nil
+
+	if needsSqlx {
+		imports = append(imports, "context")
+	}
+
+	if settings.IsNullTypeNull() {
+		columnInfo.addImport("gopkg.in/guregu/null.v4")
+	}
+
+	imports = append(imports, columnInfo.imports...)
+	sort.Strings(imports)
+
+	if settings.IsMastermindStructableRecorder {
+		externalImports = append(externalImports, "github.com/Masterminds/structable")
+	}
+
+	if settings.TagsBun {
+		externalImports = append(externalImports, "github.com/uptrace/bun")
+	}
+
+	if needsSqlx {
+		externalImports = append(externalImports, "github.com/jmoiron/sqlx")
+	}
+
+	if file.Descriptor != nil || file.TypedColumns != nil {
+		externalImports = append(externalImports, runtimeImport)
+	}
+
+	return imports, externalImports
+}
+
+// mapColumnType returns the Go type of the column by the type mapper. Columns
+// unknown to the type mapper become strings.
+func (g *generator) mapColumnType(s *settings.Settings, db database.Database, column database.Column) typemapper.Type {
+	t, ok := g.typeMapper.MapType(column)
+	if !ok {
+		t = typemapper.ForKind(typemapper.KindOther, s.Null)
+	}
+	if t.Kind == typemapper.KindOther {
+		g.warn("column %q has the unmapped data type %q; falling back to %s", column.Name, column.DataType, t.Name)
+	}
+	return t.For(db.IsNullable(column))
+}
+
+// title upper-cases the first letter of every word of s. A new caser is
+// created per call, as a caser must not be used concurrently.
+func title(s string) string {
+	return cases.Title(language.English, cases.NoLower).String(s)
+}
+
+func camelCaseString(s string) string {
+	if s == "" {
+		return s
+	}
+
+	splitted := strings.Split(s, "_")
+
+	if len(splitted) == 1 {
+		return title(s)
+	}
+
+	var cc string
+	for _, part := range splitted {
+		cc += title(strings.ToLower(part))
+	}
+	return cc
+}
+
+// toInitialisms upper-cases every word of s which is an initialism. Words are
+// separated by underscores and by the humps of camel case, so only whole
+// words are replaced: `user_id` becomes `user_ID` but `idle_timeout` stays
+// untouched.
+func toInitialisms(settings *settings.Settings, s string) string {
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		words := splitCamelCase(part)
+		for j, word := range words {
+			if initialism, ok := lookupInitialism(settings, word); ok {
+				words[j] = initialism
+			}
+		}
+		parts[i] = strings.Join(words, "")
+	}
+	return strings.Join(parts, "_")
+}
+
+// lookupInitialism returns the initialism for the given word, if any. The
+// additional initialisms of the settings are returned as specified.
+func lookupInitialism(settings *settings.Settings, word string) (string, bool) {
+	for _, initialism := range settings.Initialisms {
+		if strings.EqualFold(initialism, word) {
+			return initialism, true
+		}
+	}
+	upper := strings.ToUpper(word)
+	return upper, commonInitialisms[upper]
+}
+
+// splitCamelCase splits s before every upper-case letter which follows a
+// lower-case letter or digit, eg. `userId` becomes `user` and `Id`.
+func splitCamelCase(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev := runes[i-1]
+		if unicode.IsUpper(runes[i]) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// ValidVariableName checks for the existence of any characters
+// outside of Unicode letters, numbers and underscore.
+func validVariableName(s string) bool {
+	for _, r := range s {
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplaceSpace swaps any Unicode space characters for underscores
+// to create valid Go identifiers
+func replaceSpace(r rune) rune {
+	if unicode.IsSpace(r) || r == '\u200B' {
+		return '_'
+	}
+	return r
+}
+
+// FormatColumnName checks for invalid characters and transforms a column name
+// according to the provided settings. An explicit rename is taken as is.
+func (g *generator) formatColumnName(settings *settings.Settings, column, table string) (string, error) {
+
+	if name, ok := settings.ColumnRename(table, column); ok {
+		if name == "" || !validVariableName(name) || unicode.IsDigit(rune(name[0])) {
+			return "", fmt.Errorf("rename %q of column %q in table %q is not a valid Go identifier", name, column, table)
+		}
+		return g.escapeReserved(settings, name, fmt.Sprintf("column %q in table %q", column, table), false), nil
+	}
+
+	// Replace any whitespace with underscores
+	columnName := strings.Map(replaceSpace, column)
+	columnName = title(columnName)
+
+	if settings.IsOutputFormatCamelCase() {
+		columnName = camelCaseString(columnName)
+	}
+	if settings.ShouldInitialism() {
+		columnName = toInitialisms(settings, columnName)
+	}
+
+	// Check that the column name doesn't contain any invalid characters for Go variables
+	if !validVariableName(columnName) {
+		return "", fmt.Errorf("column name %q in table %q contains invalid characters", column, table)
+	}
+
+	// First character of an identifier in Go must be letter or _
+	// We want it to be an uppercase letter to be a public field
+	if !unicode.IsLetter(rune(columnName[0])) {
+		prefix := "X_"
+		if settings.IsOutputFormatCamelCase() {
+			prefix = "X"
+		}
+		if settings.ShouldInitialism() {
+			// Note we use the original passed in name of the column here to
+			// avoid the Title'izing of the first non-digit character as done
+			// by cases.Caser. Eg: `1fish2fish` gets transformed to `X1Fish2fish`
+			// but we want `X1fish2fish`.
+			columnName = toInitialisms(settings, column)
+		}
+		g.logger.Debug("column doesn't start with a letter; prepending prefix", "table", table, "column", column, "prefix", prefix)
+		g.warn("column %q doesn't start with a letter; prepending %q", column, prefix)
+		columnName = prefix + columnName
+	}
+
+	columnName = g.escapeReserved(settings, columnName, fmt.Sprintf("column %q in table %q", column, table), false)
+
+	return columnName, nil
+}
@@ -0,0 +1,3773 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/typemapper"
+)
+
+type mockDb struct {
+	mock.Mock
+	database.Database
+
+	tables []*database.Table
+}
+
+func newMockDb(db database.Database) *mockDb {
+	return &mockDb{Database: db}
+}
+
+func (db *mockDb) Connect() (err error) {
+	db.Called()
+	return nil
+}
+
+func (db *mockDb) Close() (err error) {
+	db.Called()
+	return nil
+}
+
+func (db *mockDb) GetTables() (tables []*database.Table, err error) {
+	db.Called()
+	return db.tables, nil
+}
+
+func (db *mockDb) PrepareGetColumnsOfTableStmt() (err error) {
+	db.Called()
+	return nil
+}
+
+func (db *mockDb) GetColumnsOfTable(table *database.Table) (err error) {
+	db.Called(table)
+	return nil
+}
+
+func (db *mockDb) PrepareGetColumnsOfViewStmt() (err error) {
+	return nil
+}
+
+func (db *mockDb) GetViews() (views []*database.Table, err error) {
+	return nil, nil
+}
+
+func (db *mockDb) GetColumnsOfView(table *database.Table) (err error) {
+	db.Called(table)
+	return nil
+}
+
+func (db *mockDb) DescribeQuery(query *database.Query) (err error) {
+	args := db.Called(query.Name)
+	described := args.Get(0).(*database.Query)
+	query.Params, query.Columns = described.Params, described.Columns
+	return args.Error(1)
+}
+
+func (db *mockDb) IsUnique(column database.Column) bool {
+	return database.IsUnique(db.Database, column)
+}
+
+func (db *mockDb) IsGenerated(column database.Column) bool {
+	return database.IsGenerated(db.Database, column)
+}
+
+type mockWriter struct {
+	mock.Mock
+}
+
+func newMockWriter() *mockWriter {
+	return &mockWriter{}
+}
+
+func (w *mockWriter) Write(tableName string, content string) error {
+	w.Called(tableName, content)
+	return nil
+}
+
+// generate generates like the command line does.
+func generate(s *settings.Settings, db database.Database, out output.Writer) error {
+	_, err := Generate(context.Background(), Options{Settings: s, Database: db, Writer: out})
+	return err
+}
+
+func TestCamelCaseString(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "empty string returns empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			desc:     "single string returns titleized single string",
+			input:    "string",
+			expected: "String",
+		},
+		{
+			desc:     "multi separated string returns CamelCase string",
+			input:    "string_with_separate_sections",
+			expected: "StringWithSeparateSections",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			actual := camelCaseString(tt.input)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestToInitialisms(t *testing.T) {
+	tests := []struct {
+		desc        string
+		input       string
+		initialisms settings.StringList
+		expected    string
+	}{
+		{
+			desc:     "id should be upper case",
+			input:    "Id",
+			expected: "ID",
+		},
+		{
+			desc:     "id at the end of camel case string should be upper case",
+			input:    "userId",
+			expected: "userID",
+		},
+		{
+			desc:     "id at the end of snake case string should be upper case",
+			input:    "user_id",
+			expected: "user_ID",
+		},
+		{
+			desc:     "id at the beginning of a word should not be upper case",
+			input:    "Iduser",
+			expected: "Iduser",
+		},
+		{
+			desc:     "id in the middle of a word should not be upper case",
+			input:    "userIdprim",
+			expected: "userIdprim",
+		},
+		{
+			desc:     "id as prefix of a snake case word should not be upper case",
+			input:    "idle_timeout",
+			expected: "idle_timeout",
+		},
+		{
+			desc:     "id as prefix of a camel case word should not be upper case",
+			input:    "IdleTimeout",
+			expected: "IdleTimeout",
+		},
+		{
+			desc:     "multiple occurrences should be upper case",
+			input:    "userIdAsJsonWithUrl",
+			expected: "userIDAsJSONWithURL",
+		},
+		{
+			desc:     "multiple snake case occurrences should be upper case",
+			input:    "Uuid_Api_Utf8",
+			expected: "UUID_API_UTF8",
+		},
+		{
+			desc:     "non replacement in the string should be return original string",
+			input:    "name",
+			expected: "name",
+		},
+		{
+			desc:     "unknown initialism should not be upper case",
+			input:    "ProductSku",
+			expected: "ProductSku",
+		},
+		{
+			desc:        "additional initialism should be upper case",
+			input:       "ProductSkuId",
+			initialisms: settings.StringList{"SKU", "IBAN"},
+			expected:    "ProductSKUID",
+		},
+		{
+			desc:        "additional initialism should be used as specified",
+			input:       "customer_iban",
+			initialisms: settings.StringList{"IBAN"},
+			expected:    "customer_IBAN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Initialisms = tt.initialisms
+			actual := toInitialisms(s, tt.input)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestInflect(t *testing.T) {
+	tests := []struct {
+		desc       string
+		input      string
+		mode       settings.Inflection
+		irregulars settings.StringMap
+		expected   string
+	}{
+		{
+			desc:     "no inflection returns original string",
+			input:    "users",
+			mode:     settings.InflectionNone,
+			expected: "users",
+		},
+		{
+			desc:     "singular of plural word",
+			input:    "users",
+			mode:     settings.InflectionSingular,
+			expected: "user",
+		},
+		{
+			desc:     "singular of snake case name inflects only the last word",
+			input:    "order_items",
+			mode:     settings.InflectionSingular,
+			expected: "order_item",
+		},
+		{
+			desc:     "singular of camel case name inflects only the last word",
+			input:    "OrderItems",
+			mode:     settings.InflectionSingular,
+			expected: "OrderItem",
+		},
+		{
+			desc:     "singular of singular word stays singular",
+			input:    "user",
+			mode:     settings.InflectionSingular,
+			expected: "user",
+		},
+		{
+			desc:     "singular of irregular word",
+			input:    "people",
+			mode:     settings.InflectionSingular,
+			expected: "person",
+		},
+		{
+			desc:     "plural of singular word",
+			input:    "order_item",
+			mode:     settings.InflectionPlural,
+			expected: "order_items",
+		},
+		{
+			desc:       "singular of configured irregular word",
+			input:      "user_data",
+			mode:       settings.InflectionSingular,
+			irregulars: settings.StringMap{"datum": "data"},
+			expected:   "user_datum",
+		},
+		{
+			desc:       "plural of configured irregular word keeps upper case",
+			input:      "UserCactus",
+			mode:       settings.InflectionPlural,
+			irregulars: settings.StringMap{"cactus": "cacti"},
+			expected:   "UserCacti",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Irregulars = tt.irregulars
+			actual := inflect(s, tt.input, tt.mode)
+			assert.Equal(t, tt.expected, actual, "test case input: "+tt.input)
+		})
+	}
+}
+
+func TestRun_Inflection(t *testing.T) {
+	s := settings.New()
+	s.StructNameInflection = settings.InflectionSingular
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "order_items",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"OrderItems",
+			"package dto\n\ntype OrderItem struct {\nID int `db:\"id\"`\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_FieldNameCollision(t *testing.T) {
+	tests := []struct {
+		desc     string
+		force    bool
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:    "colliding columns without force produce error",
+			force:   false,
+			isError: assert.Error,
+		},
+		{
+			desc:     "colliding columns with force get suffixed",
+			force:    true,
+			expected: "package dto\n\ntype Users struct {\nUserID int `db:\"user_id\"`\nUserID2 int `db:\"userId\"`\n}",
+			isError:  assert.NoError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Force = test.force
+
+			mdb := newMockDb(database.New(s))
+
+			table := &database.Table{
+				Name: "users",
+				Columns: []database.Column{
+					{
+						OrdinalPosition: 1,
+						Name:            "user_id",
+						DataType:        "integer",
+					},
+					{
+						OrdinalPosition: 2,
+						Name:            "userId",
+						DataType:        "integer",
+					},
+				},
+			}
+			mdb.tables = append(mdb.tables, table)
+
+			mdb.
+				On("GetTables").
+				Return(mdb.tables, nil)
+			mdb.
+				On("PrepareGetColumnsOfTableStmt").
+				Return(nil)
+			mdb.
+				On("GetColumnsOfTable", table)
+
+			w := newMockWriter()
+			w.On("Write", "Users", test.expected)
+
+			err := generate(s, mdb, w)
+			test.isError(t, err)
+		})
+	}
+}
+
+func TestRun_Renames(t *testing.T) {
+	s := settings.New()
+	s.Renames = settings.StringMap{
+		"tbl_cust_mstr":         "Customer",
+		"tbl_cust_mstr.cust_nm": "Name",
+	}
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "tbl_cust_mstr",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "cust_id",
+				DataType:        "integer",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "cust_nm",
+				DataType:        "text",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Customer",
+			"package dto\n\ntype Customer struct {\nCustID int `db:\"cust_id\"`\nName string `db:\"cust_nm\"`\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_InvalidTableRename(t *testing.T) {
+	tests := []struct {
+		desc   string
+		rename string
+	}{
+		{
+			desc:   "leading digit",
+			rename: "1Cust",
+		},
+		{
+			desc:   "invalid characters",
+			rename: "Cust-Mstr",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Renames = settings.StringMap{"tbl_cust_mstr": test.rename}
+
+			tables := []*database.Table{
+				{
+					Name:    "tbl_cust_mstr",
+					Columns: []database.Column{{OrdinalPosition: 1, Name: "cust_id", DataType: "integer"}},
+				},
+			}
+
+			w := newMockWriter()
+
+			_, err := Generate(context.Background(), Options{Settings: s, Tables: tables, Writer: w})
+			assert.EqualError(t, err, fmt.Sprintf(`could not create string for table "tbl_cust_mstr": rename %q of table "tbl_cust_mstr" is not a valid Go identifier`, test.rename))
+			w.AssertNotCalled(t, "Write")
+		})
+	}
+}
+
+func TestRun_GormTableName(t *testing.T) {
+	s := settings.New()
+	s.TagsGorm = true
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\ntype Users struct {\nID int `db:\"id\" gorm:\"column:id;type:integer;not null\"`\n}"+
+				"\n\n// TableName returns the name of the table.\nfunc (Users) TableName() string {\nreturn \"users\"\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_BunBaseModel(t *testing.T) {
+	s := settings.New()
+	s.TagsNoDb = true
+	s.TagsBun = true
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\nimport (\n\n\t\"github.com/uptrace/bun\"\n)\n\n"+
+				"type Users struct {\nbun.BaseModel `bun:\"table:users\"`\n\nID int `bun:\"id,notnull\"`\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestUnusedRenames(t *testing.T) {
+	tables := []*database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{Name: "id"},
+				{Name: "email"},
+			},
+		},
+	}
+
+	s := settings.New()
+	s.Renames = settings.StringMap{
+		"users":         "User",
+		"users.email":   "Mail",
+		"users.missing": "Missing",
+		"orders":        "Order",
+		"orders.id":     "OrderID",
+	}
+
+	actual := unusedRenames(s, tables)
+	assert.Equal(t, []string{"orders", "orders.id", "users.missing"}, actual)
+}
+
+func TestRun_StringTextColumns(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			dialect, _ := typemapper.DialectOf(dbType)
+			columnTypes := dialect[typemapper.KindString]
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName string `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("multi table with multi columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table1 := &database.Table{
+							Name: "test_table_1",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						table2 := &database.Table{
+							Name: "test_table_2",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table1, table2)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table1).
+							On("GetColumnsOfTable", table2)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable1",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+							).
+							On(
+								"Write",
+								"TestTable2",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 string `db:\"column_name_1\"`\nColumnName2 sql.NullString `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
+
+func TestRun_IntegerColumns(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			dialect, _ := typemapper.DialectOf(dbType)
+			columnTypes := dialect[typemapper.KindInteger]
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName int `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullInt64 `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName *int `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullInt64 `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *int `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("multi table with multi columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table1 := &database.Table{
+							Name: "test_table_1",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						table2 := &database.Table{
+							Name: "test_table_2",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table1, table2)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table1).
+							On("GetColumnsOfTable", table2)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable1",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullInt64 `db:\"column_name_1\"`\nColumnName2 int `db:\"column_name_2\"`\n}",
+							).
+							On(
+								"Write",
+								"TestTable2",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 int `db:\"column_name_1\"`\nColumnName2 sql.NullInt64 `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
+
+func TestRun_FloatColumns(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			dialect, _ := typemapper.DialectOf(dbType)
+			columnTypes := dialect[typemapper.KindFloat]
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName float64 `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullFloat64 `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName *float64 `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullFloat64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *float64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("multi table with multi columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table1 := &database.Table{
+							Name: "test_table_1",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						table2 := &database.Table{
+							Name: "test_table_2",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table1, table2)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table1).
+							On("GetColumnsOfTable", table2)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable1",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullFloat64 `db:\"column_name_1\"`\nColumnName2 float64 `db:\"column_name_2\"`\n}",
+							).
+							On(
+								"Write",
+								"TestTable2",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 float64 `db:\"column_name_1\"`\nColumnName2 sql.NullFloat64 `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
+
+func TestRun_TemporalColumns(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			dialect, _ := typemapper.DialectOf(dbType)
+			columnTypes := dialect[typemapper.KindTemporal]
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName time.Time `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullTime `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName *time.Time `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullTime `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"time\"\n)\n\ntype TestTable struct {\nColumnName1 *time.Time `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("multi table with multi columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table1 := &database.Table{
+							Name: "test_table_1",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						table2 := &database.Table{
+							Name: "test_table_2",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table1, table2)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table1).
+							On("GetColumnsOfTable", table2)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable1",
+								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullTime `db:\"column_name_1\"`\nColumnName2 time.Time `db:\"column_name_2\"`\n}",
+							).
+							On(
+								"Write",
+								"TestTable2",
+								"package dto\n\nimport (\n\t\"database/sql\"\n\t\"time\"\n)\n\ntype TestTable2 struct {\nColumnName1 time.Time `db:\"column_name_1\"`\nColumnName2 sql.NullTime `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
+
+func TestRun_BooleanColumns(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			columnTypes := []string{"boolean"}
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName bool `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullBool `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName *bool `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullBool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *bool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("multi table with multi columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table1 := &database.Table{
+							Name: "test_table_1",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						table2 := &database.Table{
+							Name: "test_table_2",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table1, table2)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table1).
+							On("GetColumnsOfTable", table2)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable1",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullBool `db:\"column_name_1\"`\nColumnName2 bool `db:\"column_name_2\"`\n}",
+							).
+							On(
+								"Write",
+								"TestTable2",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 bool `db:\"column_name_1\"`\nColumnName2 sql.NullBool `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
+
+func TestRun_UnknownColumns(t *testing.T) {
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+
+			s := settings.New()
+			s.DbType = dbType
+			db := database.New(s)
+
+			columnTypes := []string{
+				"enum",         // MySQL
+				"USER-DEFINED", // Postgres
+			}
+
+			for _, columnType := range columnTypes {
+				t.Run(columnType, func(t *testing.T) {
+
+					t.Run("single table with NOT NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName string `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName sql.NullString `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with NULL column and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName *string `db:\"column_name\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("single table with two mixed columns and native data type", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+						s.Null = settings.NullTypeNative
+
+						mdb := newMockDb(db)
+
+						table := &database.Table{
+							Name: "test_table",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable",
+								"package dto\n\ntype TestTable struct {\nColumnName1 *string `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+
+					t.Run("multi table with multi columns", func(t *testing.T) {
+						s := settings.New()
+						s.DbType = dbType
+
+						mdb := newMockDb(db)
+
+						table1 := &database.Table{
+							Name: "test_table_1",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+								},
+							},
+						}
+						table2 := &database.Table{
+							Name: "test_table_2",
+							Columns: []database.Column{
+								{
+									OrdinalPosition: 1,
+									Name:            "column_name_1",
+									DataType:        columnType,
+								},
+								{
+									OrdinalPosition: 2,
+									Name:            "column_name_2",
+									DataType:        columnType,
+									IsNullable:      "YES",
+								},
+							},
+						}
+						mdb.tables = append(mdb.tables, table1, table2)
+
+						mdb.
+							On("GetTables").
+							Return(mdb.tables, nil)
+						mdb.
+							On("PrepareGetColumnsOfTableStmt").
+							Return(nil)
+						mdb.
+							On("GetColumnsOfTable", table1).
+							On("GetColumnsOfTable", table2)
+
+						w := newMockWriter()
+						w.
+							On(
+								"Write",
+								"TestTable1",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable1 struct {\nColumnName1 sql.NullString `db:\"column_name_1\"`\nColumnName2 string `db:\"column_name_2\"`\n}",
+							).
+							On(
+								"Write",
+								"TestTable2",
+								"package dto\n\nimport (\n\t\"database/sql\"\n)\n\ntype TestTable2 struct {\nColumnName1 string `db:\"column_name_1\"`\nColumnName2 sql.NullString `db:\"column_name_2\"`\n}",
+							)
+
+						err := generate(s, mdb, w)
+						assert.NoError(t, err)
+					})
+				})
+			}
+		})
+	}
+}
+
+func TestValidVariableName(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    string
+		expected bool
+	}
+	tests := []testCase{
+		{"basic", "MyVariable_2", true},
+		{"specialChars", "MyVar;iable", false},
+		{"brackets", "MyVariabl(e)", false},
+		{"nonEnglish", "MyVαriαble", true},
+		{"spaces", "My Variable", false},
+		{"whitespace", "My		Variable", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if validVariableName(tc.input) != tc.expected {
+				t.Errorf("TestValidVariableName(%q) should be %t", tc.input, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReplaceSpace(t *testing.T) {
+	type testCase struct {
+		name     string
+		input    rune
+		expected rune
+	}
+	tests := []testCase{
+		{"letter", 'a', 'a'},
+		{"number", '7', '7'},
+		{"nonEnglish", '水', '水'},
+		{"space", ' ', '_'},
+		{"underscore", '_', '_'},
+		{"tab", '\t', '_'},
+		{"newline", '\n', '_'},
+		{"zeroWidthSpace", '​', '_'},
+		{"nonBreakingSpace", ' ', '_'},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output := replaceSpace(tc.input)
+			if output != tc.expected {
+				t.Errorf("replaceSpace(%q) = %q, expected %q", tc.input, output, tc.expected)
+			}
+		})
+	}
+
+}
+
+func TestFormatColumnName(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		type testCase struct {
+			name     string
+			input    string
+			original string
+			camel    string
+		}
+		tests := []testCase{
+			{"startWithNumber", "1fish2fish", "X_1fish2fish", "X1fish2fish"},
+			{"containsSpaces", "my column\twith\nmany\u200bspaces", "My_column_with_many_spaces", "MyColumnWithManySpaces"},
+			{"titleCase", "MyColumn", "MyColumn", "MyColumn"},
+			{"snakeCase", "my_column", "My_column", "MyColumn"},
+			{"titleSnake", "My_Column", "My_Column", "MyColumn"},
+			{"numbersOnly", "123", "X_123", "X123"},
+			{"nonEnglish", "火", "火", "火"},
+			{"nonEnglishUpper", "Λλ", "Λλ", "Λλ"},
+			{"initialism", "user_id", "User_ID", "UserID"},
+			{"initialismPrefix", "idle_timeout", "Idle_timeout", "IdleTimeout"},
+		}
+
+		camelSettings := settings.New()
+		camelSettings.OutputFormat = settings.OutputFormatCamelCase
+		t.Run("camelcase", func(t *testing.T) {
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					output, err := newGenerator(camelSettings, nil).formatColumnName(camelSettings, tc.input, "MyTable")
+					if err != nil {
+						t.Error(err)
+					} else if output != tc.camel {
+						t.Errorf("camelcase format of %q = %q, expected %q", tc.input, output, tc.camel)
+					}
+				})
+			}
+		})
+
+		originalSettings := settings.New()
+		originalSettings.OutputFormat = settings.OutputFormatOriginal
+		t.Run("original", func(t *testing.T) {
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					output, err := newGenerator(originalSettings, nil).formatColumnName(originalSettings, tc.input, "MyTable")
+					if err != nil {
+						t.Error(err)
+					} else if output != tc.original {
+						t.Errorf("originalCase format of %q = %q, expected %q", tc.input, output, tc.original)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		s := settings.New()
+		s.Renames = settings.StringMap{
+			"MyTable.cust_nm": "CustomerName",
+			"MyTable.kind":    "type",
+		}
+
+		output, err := newGenerator(s, nil).formatColumnName(s, "cust_nm", "MyTable")
+		assert.NoError(t, err)
+		assert.Equal(t, "CustomerName", output)
+
+		output, err = newGenerator(s, nil).formatColumnName(s, "kind", "MyTable")
+		assert.NoError(t, err)
+		assert.Equal(t, "type_", output)
+
+		output, err = newGenerator(s, nil).formatColumnName(s, "cust_nm", "OtherTable")
+		assert.NoError(t, err)
+		assert.Equal(t, "CustNm", output)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		type testCase struct {
+			name  string
+			input string
+		}
+		tests := []testCase{
+			{"semicolons", "MyColumn;"},
+			{"brackets", "MyColumn()"},
+			{"invalidRename", "renamed"},
+		}
+		s := settings.New()
+		s.Renames = settings.StringMap{"MyTable.renamed": "1Invalid"}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := newGenerator(s, nil).formatColumnName(s, tc.input, "MyTable")
+				if err == nil {
+					t.Errorf("formatColumnName(%q) should have thrown error but didn't", tc.input)
+				}
+			})
+		}
+	})
+}
+
+func TestRun_Template(t *testing.T) {
+	tests := []struct {
+		desc     string
+		template string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc: "default template renders column comments",
+			column: database.Column{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				Comment:         sql.NullString{String: "identifier of\nthe user", Valid: true},
+			},
+			expected: "package dto\n\ntype Users struct {\n// identifier of\n// the user\nID int `db:\"id\"`\n}",
+		},
+		{
+			desc: "custom template renders the model",
+			template: "package {{.Package}}\n\n" +
+				"// {{.StructName}} is a {{singularize .Table.Name}}.\n" +
+				"type {{.StructName}} struct {\n" +
+				"{{range .Fields}}{{.Name}} {{.Type}} {{.Tag}}\n{{end}}}\n\n" +
+				"const {{.StructName}}Table = {{quote .Table.Name}}\n" +
+				"const {{.StructName}}PK = {{range .PrimaryKeys}}{{quote .Column.Name}}{{end}}\n",
+			column: database.Column{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			expected: "package dto\n\n// Users is a user.\ntype Users struct {\nID int `db:\"id\"`\n}\n\n" +
+				"const UsersTable = \"users\"\nconst UsersPK = \"id\"\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			if test.template != "" {
+				s.Template = filepath.Join(t.TempDir(), "struct.tmpl")
+				err := os.WriteFile(s.Template, []byte(test.template), 0o600)
+				assert.NoError(t, err)
+			}
+
+			mdb := newMockDb(database.New(s))
+
+			table := &database.Table{
+				Name:    "users",
+				Columns: []database.Column{test.column},
+			}
+			mdb.tables = append(mdb.tables, table)
+
+			mdb.
+				On("GetTables").
+				Return(mdb.tables, nil)
+			mdb.
+				On("PrepareGetColumnsOfTableStmt").
+				Return(nil)
+			mdb.
+				On("GetColumnsOfTable", table)
+
+			w := newMockWriter()
+			w.
+				On(
+					"Write",
+					"Users",
+					test.expected,
+				)
+
+			err := generate(s, mdb, w)
+			assert.NoError(t, err)
+			w.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_TemplateError(t *testing.T) {
+	s := settings.New()
+	s.Template = filepath.Join(t.TempDir(), "struct.tmpl")
+	err := os.WriteFile(s.Template, []byte("{{.StructName"), 0o600)
+	assert.NoError(t, err)
+
+	err = generate(s, newMockDb(database.New(s)), newMockWriter())
+	assert.Error(t, err)
+}
+
+func TestRun_Columns(t *testing.T) {
+	s := settings.New()
+	s.GenerateColumns = true
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "email",
+				DataType:        "varchar",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\ntype Users struct {\nID int `db:\"id\"`\nEmail string `db:\"email\"`\n}\n\n"+
+				"// TableName returns the name of the table.\nfunc (Users) TableName() string {\nreturn \"users\"\n}\n\n"+
+				"// UsersColumns are the names of the columns of the table.\n"+
+				"var UsersColumns = struct {\nID string\nEmail string\n}{\nID: \"id\",\nEmail: \"email\",\n}\n\n"+
+				"// UsersAllColumns are the names of all columns of the table in their order.\n"+
+				"var UsersAllColumns = []string{\nUsersColumns.ID,\nUsersColumns.Email,\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_ColumnsCollision(t *testing.T) {
+	s := settings.New()
+	s.GenerateColumns = true
+
+	mdb := newMockDb(database.New(s))
+
+	users := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+	usersColumns := &database.Table{
+		Name: "users_columns",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, users, usersColumns)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", users)
+	mdb.
+		On("GetColumnsOfTable", usersColumns)
+
+	w := newMockWriter()
+	w.
+		On("Write", "Users", mock.Anything)
+
+	err := generate(s, mdb, w)
+	assert.EqualError(t, err, `could not create string for table "users_columns": `+
+		`table "users_columns" collides with column names of table "users" as "UsersColumns"`)
+}
+
+func TestRun_Repository(t *testing.T) {
+	s := settings.New()
+	s.GenerateRepository = true
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "email",
+				DataType:        "varchar",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On("Write", "Users", mock.Anything)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+
+	actual, err := format.Source([]byte(w.Calls[0].Arguments.String(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, `package dto
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Users struct {
+	ID    int    `+"`db:\"id\"`"+`
+	Email string `+"`db:\"email\"`"+`
+}
+
+// GetUsersByID returns the row of the table users with the given primary key.
+func GetUsersByID(ctx context.Context, db sqlx.QueryerContext, id int) (*Users, error) {
+	var row Users
+	err := sqlx.GetContext(ctx, db, &row, "SELECT \"id\", \"email\" FROM \"users\" WHERE \"id\" = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// ListUsers returns all rows of the table users.
+func ListUsers(ctx context.Context, db sqlx.QueryerContext) ([]Users, error) {
+	var rows []Users
+	err := sqlx.SelectContext(ctx, db, &rows, "SELECT \"id\", \"email\" FROM \"users\"")
+	return rows, err
+}
+
+// InsertUsers inserts the row into the table users. The auto increment columns of the row get set.
+func InsertUsers(ctx context.Context, db sqlx.ExtContext, row *Users) error {
+	return sqlx.GetContext(ctx, db, row, "INSERT INTO \"users\" (\"email\") VALUES ($1) RETURNING \"id\"", row.Email)
+}
+
+// UpdateUsers updates the row of the table users by its primary key and returns the number of affected rows.
+func UpdateUsers(ctx context.Context, db sqlx.ExecerContext, row *Users) (int64, error) {
+	result, err := db.ExecContext(ctx, "UPDATE \"users\" SET \"email\" = $1 WHERE \"id\" = $2", row.Email, row.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteUsersByID deletes the row of the table users with the given primary key and returns the number of affected rows.
+func DeleteUsersByID(ctx context.Context, db sqlx.ExecerContext, id int) (int64, error) {
+	result, err := db.ExecContext(ctx, "DELETE FROM \"users\" WHERE \"id\" = $1", id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+`, string(actual))
+}
+
+func TestRun_Upsert(t *testing.T) {
+	s := settings.New()
+	s.GenerateUpsert = true
+
+	mdb := newMockDb(database.New(s))
+
+	users := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "email",
+				DataType:        "varchar",
+			},
+		},
+	}
+	logs := &database.Table{
+		Name: "logs",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "message",
+				DataType:        "text",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, users, logs)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", users)
+	mdb.
+		On("GetColumnsOfTable", logs)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\nimport (\n\t\"context\"\n\n\t\"github.com/jmoiron/sqlx\"\n)\n\n"+
+				"type Users struct {\nID int `db:\"id\"`\nEmail string `db:\"email\"`\n}\n\n"+
+				"// UpsertUsersQuery inserts a row into the table users or updates it if it already exists.\n"+
+				"const UpsertUsersQuery = \"INSERT INTO \\\"users\\\" (\\\"id\\\", \\\"email\\\") VALUES ($1, $2) "+
+				"ON CONFLICT (\\\"id\\\") DO UPDATE SET \\\"email\\\" = excluded.\\\"email\\\"\"\n\n"+
+				"// UpsertUsers inserts the row into the table users or updates it if it already exists.\n"+
+				"func UpsertUsers(ctx context.Context, db sqlx.ExecerContext, row *Users) error {\n"+
+				"\t_, err := db.ExecContext(ctx, UpsertUsersQuery, row.ID, row.Email)\n\treturn err\n}",
+		)
+	w.
+		On(
+			"Write",
+			"Logs",
+			"package dto\n\ntype Logs struct {\nMessage string `db:\"message\"`\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_Scan(t *testing.T) {
+	s := settings.New()
+	s.GenerateScan = true
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "email",
+				DataType:        "varchar",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\ntype Users struct {\nID int `db:\"id\"`\nEmail string `db:\"email\"`\n}\n\n"+
+				"// ScanRow scans the columns of the row in their order into the fields.\n"+
+				"func (u *Users) ScanRow(row interface{ Scan(...any) error }) error {\n"+
+				"return row.Scan(&u.ID, &u.Email)\n}\n\n"+
+				"// Values returns the values of the fields in the order of the columns.\n"+
+				"func (u *Users) Values() []any {\nreturn []any{u.ID, u.Email}\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_Descriptor(t *testing.T) {
+	s := settings.New()
+	s.GenerateDescriptor = true
+
+	mdb := newMockDb(database.New(s))
+
+	users := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "integer",
+				DefaultValue:    sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+				ConstraintType:  sql.NullString{String: "PRIMARY KEY", Valid: true},
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "email",
+				DataType:        "varchar",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "domain",
+				DataType:        "varchar",
+				IsGenerated:     "ALWAYS",
+			},
+		},
+	}
+	logs := &database.Table{
+		Name: "logs",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "message",
+				DataType:        "text",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, users, logs)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", users)
+	mdb.
+		On("GetColumnsOfTable", logs)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\nimport (\n\n\t\"github.com/fraenky8/tables-to-go/pkg/runtime\"\n)\n\n"+
+				"type Users struct {\nID int `db:\"id\"`\nEmail string `db:\"email\"`\nDomain string `db:\"domain\"`\n}\n\n"+
+				"// UsersDescriptor describes the table users for runtime.Repository.\n"+
+				"var UsersDescriptor = runtime.Descriptor{\n"+
+				"\tTable: \"users\",\n"+
+				"\tColumns: []string{\"id\", \"email\", \"domain\"},\n"+
+				"\tPrimaryKey: []string{\"id\"},\n"+
+				"\tAutoIncrement: []string{\"id\"},\n"+
+				"\tGenerated: []string{\"domain\"},\n"+
+				"\tDialect: runtime.DialectPostgresql,\n}",
+		)
+	w.
+		On(
+			"Write",
+			"Logs",
+			"package dto\n\nimport (\n\n\t\"github.com/fraenky8/tables-to-go/pkg/runtime\"\n)\n\n"+
+				"type Logs struct {\nMessage string `db:\"message\"`\n}\n\n"+
+				"// LogsDescriptor describes the table logs for runtime.Repository.\n"+
+				"var LogsDescriptor = runtime.Descriptor{\n"+
+				"\tTable: \"logs\",\n"+
+				"\tColumns: []string{\"message\"},\n"+
+				"\tDialect: runtime.DialectPostgresql,\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_TypedColumns(t *testing.T) {
+	s := settings.New()
+	s.GenerateTypedColumns = true
+	s.DbType = settings.DBTypeMySQL
+
+	mdb := newMockDb(database.New(s))
+
+	table := &database.Table{
+		Name: "users",
+		Columns: []database.Column{
+			{
+				OrdinalPosition: 1,
+				Name:            "id",
+				DataType:        "int",
+			},
+			{
+				OrdinalPosition: 2,
+				Name:            "email",
+				DataType:        "varchar",
+			},
+			{
+				OrdinalPosition: 3,
+				Name:            "score",
+				DataType:        "decimal",
+			},
+			{
+				OrdinalPosition: 4,
+				Name:            "created_at",
+				DataType:        "datetime",
+			},
+			{
+				OrdinalPosition: 5,
+				Name:            "table",
+				DataType:        "varchar",
+			},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+
+	mdb.
+		On("GetTables").
+		Return(mdb.tables, nil)
+	mdb.
+		On("PrepareGetColumnsOfTableStmt").
+		Return(nil)
+	mdb.
+		On("GetColumnsOfTable", table)
+
+	t.Run("collision with the embedded table produces error", func(t *testing.T) {
+		w := newMockWriter()
+		err := generate(s, mdb, w)
+		assert.Error(t, err)
+	})
+
+	t.Run("collision with the embedded table gets resolved with force", func(t *testing.T) {
+		s.Force = true
+		defer func() { s.Force = false }()
+
+		w := newMockWriter()
+		w.
+			On(
+				"Write",
+				"Users",
+				"package dto\n\nimport (\n\t\"time\"\n\n\t\"github.com/fraenky8/tables-to-go/pkg/runtime\"\n)\n\n"+
+					"type Users struct {\nID int `db:\"id\"`\nEmail string `db:\"email\"`\nScore float64 `db:\"score\"`\n"+
+					"CreatedAt time.Time `db:\"created_at\"`\nTable2 string `db:\"table\"`\n}\n\n"+
+					"// UsersTable are the typed columns of the table users to build queries.\n"+
+					"var UsersTable = struct {\n\truntime.Table\n"+
+					"\tID runtime.IntColumn\n\tEmail runtime.StringColumn\n\tScore runtime.FloatColumn\n"+
+					"\tCreatedAt runtime.TimeColumn\n\tTable2 runtime.StringColumn\n}{\n"+
+					"\tTable: runtime.NewTable(\"users\", runtime.DialectMySQL),\n"+
+					"\tID: runtime.NewIntColumn(\"id\"),\n"+
+					"\tEmail: runtime.NewStringColumn(\"email\"),\n"+
+					"\tScore: runtime.NewFloatColumn(\"score\"),\n"+
+					"\tCreatedAt: runtime.NewTimeColumn(\"created_at\"),\n"+
+					"\tTable2: runtime.NewStringColumn(\"table\"),\n}",
+			)
+
+		err := generate(s, mdb, w)
+		assert.NoError(t, err)
+		w.AssertExpectations(t)
+	})
+}
+
+func TestRun_SQLFiles(t *testing.T) {
+	s := settings.New()
+	s.SQLFiles = settings.StringList{filepath.Join(t.TempDir(), "users.sql")}
+	err := os.WriteFile(s.SQLFiles[0], []byte(`-- name: GetUserByEmail :one
+SELECT id, email, created_at FROM users WHERE email = $1;
+
+-- name: ListUsers :many
+SELECT id FROM users LIMIT $1;
+
+-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1;
+`), 0o600)
+	assert.NoError(t, err)
+
+	mdb := newMockDb(database.New(s))
+	mdb.
+		On("DescribeQuery", "GetUserByEmail").
+		Return(&database.Query{
+			Params: []database.Column{
+				{OrdinalPosition: 1, Name: "email", DataType: "character varying"},
+			},
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+				{OrdinalPosition: 2, Name: "email", DataType: "character varying", IsNullable: "YES"},
+				{OrdinalPosition: 3, Name: "created_at", DataType: "timestamp with time zone", IsNullable: "NO"},
+			},
+		}, nil)
+	mdb.
+		On("DescribeQuery", "ListUsers").
+		Return(&database.Query{
+			Params: []database.Column{
+				{OrdinalPosition: 1},
+			},
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+			},
+		}, nil)
+	mdb.
+		On("DescribeQuery", "DeleteUser").
+		Return(&database.Query{
+			Params: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "integer"},
+			},
+		}, nil)
+
+	w := newMockWriter()
+	w.
+		On("Write", "UsersQueries", mock.Anything)
+
+	err = generate(s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+
+	actual, err := format.Source([]byte(w.Calls[0].Arguments.String(1)))
+	assert.NoError(t, err)
+	assert.Equal(t, `package dto
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GetUserByEmailQuery is the statement of the query GetUserByEmail.
+const GetUserByEmailQuery = "SELECT id, email, created_at FROM users WHERE email = $1"
+
+// GetUserByEmailRow is a result row of the query GetUserByEmail.
+type GetUserByEmailRow struct {
+	ID        int            `+"`db:\"id\"`"+`
+	Email     sql.NullString `+"`db:\"email\"`"+`
+	CreatedAt time.Time      `+"`db:\"created_at\"`"+`
+}
+
+// GetUserByEmail executes the query GetUserByEmail and returns its single row. If there is no row, sql.ErrNoRows is returned.
+func GetUserByEmail(ctx context.Context, db sqlx.QueryerContext, email string) (*GetUserByEmailRow, error) {
+	var row GetUserByEmailRow
+	err := sqlx.GetContext(ctx, db, &row, GetUserByEmailQuery, email)
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// ListUsersQuery is the statement of the query ListUsers.
+const ListUsersQuery = "SELECT id FROM users LIMIT $1"
+
+// ListUsersRow is a result row of the query ListUsers.
+type ListUsersRow struct {
+	ID int `+"`db:\"id\"`"+`
+}
+
+// ListUsers executes the query ListUsers and returns its rows.
+func ListUsers(ctx context.Context, db sqlx.QueryerContext, arg1 any) ([]ListUsersRow, error) {
+	var rows []ListUsersRow
+	err := sqlx.SelectContext(ctx, db, &rows, ListUsersQuery, arg1)
+	return rows, err
+}
+
+// DeleteUserQuery is the statement of the query DeleteUser.
+const DeleteUserQuery = "DELETE FROM users WHERE id = $1"
+
+// DeleteUser executes the query DeleteUser and returns the number of affected rows.
+func DeleteUser(ctx context.Context, db sqlx.ExecerContext, id int) (int64, error) {
+	result, err := db.ExecContext(ctx, DeleteUserQuery, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+`, string(actual))
+}
+
+func TestRun_SQLFilesCollision(t *testing.T) {
+	s := settings.New()
+	s.SQLFiles = settings.StringList{filepath.Join(t.TempDir(), "users.sql")}
+	err := os.WriteFile(s.SQLFiles[0], []byte(`-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1;
+
+-- name: DeleteUserQuery :exec
+DELETE FROM users;
+`), 0o600)
+	assert.NoError(t, err)
+
+	mdb := newMockDb(database.New(s))
+	mdb.
+		On("DescribeQuery", mock.Anything).
+		Return(&database.Query{}, nil)
+
+	w := newMockWriter()
+
+	err = generate(s, mdb, w)
+	assert.Error(t, err)
+}
+
+func TestRun_Query(t *testing.T) {
+	s := settings.New()
+	s.Query = "SELECT u.email, count(o.id) AS orders FROM users AS u JOIN orders AS o ON o.user_id = u.id GROUP BY u.email;"
+	s.QueryName = "MonthlyReport"
+
+	mdb := newMockDb(database.New(s))
+	mdb.
+		On("DescribeQuery", "MonthlyReport").
+		Return(&database.Query{
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "email", DataType: "character varying", IsNullable: "NO"},
+				{OrdinalPosition: 2, Name: "orders", DataType: "bigint", IsNullable: "YES"},
+			},
+		}, nil)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"MonthlyReport",
+			"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+				"type MonthlyReport struct {\nEmail string `db:\"email\"`\nOrders sql.NullInt64 `db:\"orders\"`\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	mdb.AssertExpectations(t)
+	w.AssertExpectations(t)
+}
+
+func TestRun_QueryDuplicateColumns(t *testing.T) {
+	tests := []struct {
+		desc     string
+		force    bool
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:    "duplicate result columns without force produce error",
+			force:   false,
+			isError: assert.Error,
+		},
+		{
+			desc:     "duplicate result columns with force get suffixed",
+			force:    true,
+			expected: "package dto\n\ntype Report struct {\nID int `db:\"id\"`\nID2 int `db:\"id\"`\n}",
+			isError:  assert.NoError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Force = test.force
+			s.Query = "SELECT u.id, o.id FROM users AS u JOIN orders AS o ON o.user_id = u.id ORDER BY u.id -- all"
+			s.QueryName = "Report"
+
+			mdb := newMockDb(database.New(s))
+			mdb.
+				On("DescribeQuery", "Report").
+				Return(&database.Query{
+					Columns: []database.Column{
+						{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+						{OrdinalPosition: 2, Name: "id", DataType: "integer", IsNullable: "NO"},
+					},
+				}, nil)
+
+			w := newMockWriter()
+			w.On("Write", "Report", test.expected)
+
+			err := generate(s, mdb, w)
+			test.isError(t, err)
+		})
+	}
+}
+
+func TestRun_NullablePrimitiveTemporalColumn(t *testing.T) {
+	s := settings.New()
+	s.Null = settings.NullTypePrimitive
+
+	mdb := newMockDb(database.New(s))
+	table := &database.Table{
+		Name: "events",
+		Columns: []database.Column{
+			{OrdinalPosition: 1, Name: "happened_at", DataType: "timestamp", IsNullable: "YES"},
+		},
+	}
+	mdb.tables = append(mdb.tables, table)
+	mdb.On("GetTables")
+	mdb.On("PrepareGetColumnsOfTableStmt")
+	mdb.On("GetColumnsOfTable", table)
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Events",
+			"package dto\n\nimport (\n\t\"time\"\n)\n\n"+
+				"type Events struct {\nHappenedAt *time.Time `db:\"happened_at\"`\n}",
+		)
+
+	err := generate(s, mdb, w)
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestRun_RuntimeWithoutDialect(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func(s *settings.Settings)
+	}{
+		{
+			desc: "typed columns",
+			settings: func(s *settings.Settings) {
+				s.GenerateTypedColumns = true
+			},
+		},
+		{
+			desc: "descriptor",
+			settings: func(s *settings.Settings) {
+				s.GenerateDescriptor = true
+			},
+		},
+		{
+			desc: "repository",
+			settings: func(s *settings.Settings) {
+				s.GenerateRepository = true
+			},
+		},
+		{
+			desc: "upsert",
+			settings: func(s *settings.Settings) {
+				s.GenerateUpsert = true
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = settings.DBTypeGeneric
+			test.settings(s)
+
+			mdb := newMockDb(database.New(s))
+			w := newMockWriter()
+
+			err := generate(s, mdb, w)
+			assert.Error(t, err)
+			mdb.AssertNotCalled(t, "GetTables")
+			w.AssertNotCalled(t, "Write")
+		})
+	}
+}
+
+// failingWriter fails to write the files of the given names.
+type failingWriter struct {
+	failing map[string]bool
+}
+
+func (w failingWriter) Write(fileName string, _ string) error {
+	if w.failing[fileName] {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
+func TestGenerate_Result(t *testing.T) {
+	s := settings.New()
+	s.Force = true
+	s.Renames = settings.StringMap{"payments": "Payment"}
+
+	tables := []*database.Table{
+		{Name: "users", Columns: []database.Column{
+			// user_id is part of two constraints and only counted once.
+			{OrdinalPosition: 1, Name: "user_id", DataType: "integer", ColumnKey: "PRI"},
+			{OrdinalPosition: 1, Name: "user_id", DataType: "integer", ColumnKey: "UNI"},
+			{OrdinalPosition: 2, Name: "userId", DataType: "integer"},
+			{OrdinalPosition: 3, Name: "settings", DataType: "json"},
+		}},
+		{Name: "orders", Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer"}}},
+	}
+
+	result, err := Generate(context.Background(), Options{
+		Settings: s,
+		Tables:   tables,
+		Writer:   failingWriter{failing: map[string]bool{"Orders": true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{
+		Files: []string{"Users"},
+		Skipped: []Skipped{
+			{Kind: "table", Name: "orders", Reason: `could not write struct for table "orders": disk full`},
+		},
+		Tables: []*TableResult{
+			{
+				Kind:    "table",
+				Name:    "users",
+				Status:  StatusGenerated,
+				Columns: 3,
+				File:    "Users",
+				Warnings: []string{
+					`column "userId" of table "users" collides with column "user_id" of table "users" as "UserID"; renaming to "UserID2"`,
+					`column "settings" has the unmapped data type "json"; falling back to string`,
+				},
+			},
+			{
+				Kind:    "table",
+				Name:    "orders",
+				Status:  StatusSkipped,
+				Columns: 1,
+				Error:   `could not write struct for table "orders": disk full`,
+			},
+		},
+		Warnings: []string{`rename "payments" does not match any table, view or column`},
+	}, result)
+
+	s.Force = false
+	s.Renames = nil
+	result, err = Generate(context.Background(), Options{
+		Settings: s,
+		Tables:   tables[1:],
+		Writer:   failingWriter{failing: map[string]bool{"Orders": true}},
+	})
+	assert.EqualError(t, err, `could not write struct for table "orders": disk full`)
+	assert.Equal(t, &Result{
+		Tables: []*TableResult{
+			{
+				Kind:    "table",
+				Name:    "orders",
+				Status:  StatusFailed,
+				Columns: 1,
+				Error:   `could not write struct for table "orders": disk full`,
+			},
+		},
+	}, result)
+}
+
+func TestGenerate_ResultSQLFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := settings.New()
+	s.Force = true
+	s.SQLFiles = settings.StringList{filepath.Join(dir, "users.sql"), filepath.Join(dir, "orders.sql")}
+	files := map[string]string{
+		s.SQLFiles[0]: "-- name: DeleteUser :exec\nDELETE FROM users WHERE id = $1;\n\n-- name: ListUsers :many\nSELECT id FROM users;\n",
+		s.SQLFiles[1]: "-- name: DeleteOrder :exec\nDELETE FROM orders WHERE id = $1;\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("expected non error, got: %s", err)
+		}
+	}
+
+	mdb := newMockDb(database.New(s))
+	mdb.
+		On("DescribeQuery", "DeleteUser").
+		Return(&database.Query{}, nil)
+	mdb.
+		On("DescribeQuery", "ListUsers").
+		Return(&database.Query{}, errors.New("relation \"users\" does not exist"))
+	mdb.
+		On("DescribeQuery", "DeleteOrder").
+		Return(&database.Query{}, nil)
+
+	result, err := Generate(context.Background(), Options{
+		Settings: s,
+		Database: mdb,
+		Writer:   failingWriter{failing: map[string]bool{"OrdersQueries": true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{
+		Files: []string{"UsersQueries"},
+		Skipped: []Skipped{
+			{Kind: "query", Name: "ListUsers", Reason: `could not create query "ListUsers": could not describe query: relation "users" does not exist`},
+			{Kind: "file", Name: s.SQLFiles[1], Reason: fmt.Sprintf("could not write queries of file %q: disk full", s.SQLFiles[1])},
+		},
+		Tables: []*TableResult{
+			{
+				Kind:     "file",
+				Name:     s.SQLFiles[0],
+				Status:   StatusGenerated,
+				File:     "UsersQueries",
+				Warnings: []string{`skipping query "ListUsers": could not create query "ListUsers": could not describe query: relation "users" does not exist`},
+			},
+			{
+				Kind:   "file",
+				Name:   s.SQLFiles[1],
+				Status: StatusSkipped,
+				Error:  fmt.Sprintf("could not write queries of file %q: disk full", s.SQLFiles[1]),
+			},
+		},
+	}, result)
+
+	s.Force = false
+	s.SQLFiles = s.SQLFiles[:1]
+	result, err = Generate(context.Background(), Options{Settings: s, Database: mdb, Writer: failingWriter{}})
+	expected := fmt.Sprintf(`could not create string for file %q: could not create query "ListUsers": could not describe query: relation "users" does not exist`, s.SQLFiles[0])
+	assert.EqualError(t, err, expected)
+	assert.Equal(t, []*TableResult{
+		{Kind: "file", Name: s.SQLFiles[0], Status: StatusFailed, Error: expected},
+	}, result.Tables)
+}
+
+func TestGenerate_ResultQuery(t *testing.T) {
+	s := settings.New()
+	s.Query = "SELECT id FROM users"
+	s.QueryName = "UserIDs"
+
+	mdb := newMockDb(database.New(s))
+	mdb.
+		On("DescribeQuery", "UserIDs").
+		Return(&database.Query{
+			Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"}},
+		}, nil)
+
+	result, err := Generate(context.Background(), Options{Settings: s, Database: mdb, Writer: failingWriter{}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*TableResult{
+		{Kind: "query", Name: "UserIDs", Status: StatusGenerated, Columns: 1, File: "UserIDs"},
+	}, result.Tables)
+}
+
+func TestGenerate_Logger(t *testing.T) {
+	tables := []*database.Table{
+		{Name: "users", Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer"}}},
+		{Name: "orders", Columns: []database.Column{{OrdinalPosition: 1, Name: "id", DataType: "integer"}}},
+	}
+
+	tests := []struct {
+		desc        string
+		settings    func(s *settings.Settings)
+		contains    []string
+		notContains []string
+	}{
+		{
+			desc:     "default logs progress and skipped tables",
+			settings: func(s *settings.Settings) {},
+			contains: []string{
+				`level=INFO msg=running db_type=pg`,
+				`level=WARN msg="skipping table" table=orders error="could not write struct for table \"orders\": disk full"`,
+				`level=INFO msg=done`,
+			},
+			notContains: []string{"DEBUG"},
+		},
+		{
+			desc:     "verbose logs the tables",
+			settings: func(s *settings.Settings) { s.Verbose = true },
+			contains: []string{
+				`level=DEBUG msg="found tables" count=2`,
+				`level=DEBUG msg="processing table" table=users`,
+			},
+			notContains: []string{"processing column"},
+		},
+		{
+			desc:     "v-verbose logs the columns",
+			settings: func(s *settings.Settings) { s.VVerbose = true },
+			contains: []string{
+				`level=DEBUG-4 msg="processing column" table=users column=id`,
+			},
+		},
+		{
+			desc:     "json format",
+			settings: func(s *settings.Settings) { s.LogFormat = settings.LogFormatJSON },
+			contains: []string{
+				`"level":"WARN","msg":"skipping table","table":"orders"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Force = true
+			test.settings(s)
+
+			var buf bytes.Buffer
+			s.Logger = s.NewLogger(&buf)
+
+			_, err := Generate(context.Background(), Options{
+				Settings: s,
+				Tables:   tables,
+				Writer:   failingWriter{failing: map[string]bool{"Orders": true}},
+			})
+			assert.NoError(t, err)
+
+			for _, expected := range test.contains {
+				assert.Contains(t, buf.String(), expected)
+			}
+			for _, unexpected := range test.notContains {
+				assert.NotContains(t, buf.String(), unexpected)
+			}
+		})
+	}
+}
+
+func TestGenerate_Tables(t *testing.T) {
+	s := settings.New()
+	s.DbType = settings.DBTypeMySQL
+
+	tables := []*database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "int", ColumnKey: "PRI", Extra: "auto_increment"},
+				{OrdinalPosition: 2, Name: "email", DataType: "varchar", IsNullable: "YES"},
+			},
+		},
+	}
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\nimport (\n\t\"database/sql\"\n)\n\n"+
+				"type Users struct {\nID int `db:\"id\"`\nEmail sql.NullString `db:\"email\"`\n}",
+		)
+
+	result, err := Generate(context.Background(), Options{Settings: s, Tables: tables, Writer: w})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Users"}, result.Files)
+	w.AssertExpectations(t)
+
+	s.Query = "SELECT 1 AS one"
+	s.QueryName = "One"
+	_, err = Generate(context.Background(), Options{Settings: s, Tables: tables, Writer: w})
+	assert.Error(t, err)
+}
+
+func TestGenerate_TypeMapper(t *testing.T) {
+	s := settings.New()
+
+	tables := []*database.Table{
+		{
+			Name: "users",
+			Columns: []database.Column{
+				{OrdinalPosition: 1, Name: "id", DataType: "uuid", IsNullable: "NO"},
+			},
+		},
+	}
+
+	w := newMockWriter()
+	w.
+		On(
+			"Write",
+			"Users",
+			"package dto\n\nimport (\n\t\"github.com/google/uuid\"\n)\n\n"+
+				"type Users struct {\nID uuid.UUID `db:\"id\"`\n}",
+		)
+
+	_, err := Generate(context.Background(), Options{
+		Settings: s,
+		Tables:   tables,
+		Writer:   w,
+		TypeMapper: typemapper.Chain(
+			typemapper.DataTypes{"uuid": {Kind: typemapper.KindString, Name: "uuid.UUID", Imports: []string{"github.com/google/uuid"}}},
+			typemapper.Default(s.DbType, s.Null),
+		),
+	})
+	assert.NoError(t, err)
+	w.AssertExpectations(t)
+}
+
+func TestGenerate_Concurrent(t *testing.T) {
+	names := []string{"users", "orders", "items", "accounts"}
+
+	var wg sync.WaitGroup
+	results := make([]*Result, len(names))
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			s := settings.New()
+			s.Force = true
+			tables := []*database.Table{
+				{
+					Name: name,
+					Columns: []database.Column{
+						{OrdinalPosition: 1, Name: "id", DataType: "integer", IsNullable: "NO"},
+						{OrdinalPosition: 2, Name: "1st", DataType: "integer", IsNullable: "NO"},
+					},
+				},
+			}
+
+			w := newMockWriter()
+			w.On("Write", mock.Anything, mock.Anything)
+
+			results[i], _ = Generate(context.Background(), Options{Settings: s, Tables: tables, Writer: w})
+		}(i, name)
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		if assert.Len(t, results[i].Tables, 1) {
+			assert.Equal(t, name, results[i].Tables[0].Name)
+			assert.Equal(t, []string{`column "1st" doesn't start with a letter; prepending "X"`}, results[i].Tables[0].Warnings)
+		}
+		assert.Len(t, results[i].Files, 1)
+	}
+}
+
+func TestGenerate_Options(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts Options
+	}{
+		{
+			desc: "without settings",
+			opts: Options{Tables: []*database.Table{}, Writer: newMockWriter()},
+		},
+		{
+			desc: "without writer",
+			opts: Options{Settings: settings.New(), Tables: []*database.Table{}},
+		},
+		{
+			desc: "without database and tables",
+			opts: Options{Settings: settings.New(), Writer: newMockWriter()},
+		},
+		{
+			desc: "with database and tables",
+			opts: Options{
+				Settings: settings.New(),
+				Database: database.New(settings.New()),
+				Tables:   []*database.Table{},
+				Writer:   newMockWriter(),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			result, err := Generate(context.Background(), test.opts)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestGenerate_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := Generate(ctx, Options{
+		Settings: settings.New(),
+		Tables:   []*database.Table{{Name: "users"}},
+		Writer:   newMockWriter(),
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, result.Files)
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// predeclared are the predeclared identifiers of Go. A struct with one of these
+// names would shadow the builtin for the whole generated package.
+var predeclared = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true,
+	"complex64": true, "complex128": true, "error": true, "float32": true,
+	"float64": true, "int": true, "int8": true, "int16": true, "int32": true,
+	"int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true, "make": true,
+	"max": true, "min": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+}
+
+// identifiers keeps track of the generated names, mapped to the database
+// object they originate from, to detect collisions between them.
+type identifiers map[string]string
+
+// reservedFieldNames returns the field names occupied by the generated code
+// itself, eg. the embedded structable.Recorder or generated methods.
+func reservedFieldNames(settings *settings.Settings) identifiers {
+	reserved := identifiers{}
+	if settings.IsMastermindStructableRecorder {
+		reserved["Recorder"] = "structable.Recorder"
+	}
+	if settings.TagsGorm || settings.GenerateColumns {
+		reserved["TableName"] = "method TableName()"
+	}
+	if settings.TagsBun {
+		reserved["BaseModel"] = "bun.BaseModel"
+	}
+	if settings.GenerateTypedColumns {
+		reserved["Table"] = "runtime.Table of the typed columns"
+		reserved["Select"] = "method Select() of runtime.Table"
+		reserved["TableName"] = "method TableName() of runtime.Table"
+	}
+	if settings.GenerateScan {
+		reserved["ScanRow"] = "method ScanRow()"
+		reserved["Values"] = "method Values()"
+	}
+	return reserved
+}
+
+// addIdentifier adds the name originating from origin to the identifiers and
+// returns it. If the name is already taken, it gets suffixed by an ascending
+// number to resolve the collision deterministically. Resolving is only done
+// with Force, otherwise the collision is returned as error.
+func (g *generator) addIdentifier(ids identifiers, settings *settings.Settings, name, origin string) (string, error) {
+	resolved := name
+	for i := 2; ids[resolved] != ""; i++ {
+		resolved = name + strconv.Itoa(i)
+	}
+
+	if resolved != name {
+		if !settings.Force {
+			return "", fmt.Errorf("%s collides with %s as %q", origin, ids[name], name)
+		}
+		g.logger.Warn("identifier collides; renaming", "origin", origin, "collides_with", ids[name], "name", name, "renamed", resolved)
+		g.warn("%s collides with %s as %q; renaming to %q", origin, ids[name], name, resolved)
+	}
+
+	ids[resolved] = origin
+
+	return resolved, nil
+}
+
+// escapeReserved appends an underscore to names which are Go keywords. Names
+// of types additionally must not be predeclared identifiers.
+func (g *generator) escapeReserved(settings *settings.Settings, name, origin string, isType bool) string {
+	if !token.IsKeyword(name) && !(isType && predeclared[name]) {
+		return name
+	}
+	g.logger.Debug("reserved identifier; appending underscore", "origin", origin, "name", name)
+	g.warn("%s is a reserved identifier as %q; appending %q", origin, name, "_")
+	return name + "_"
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestGenerator_AddIdentifier(t *testing.T) {
+	tests := []struct {
+		desc     string
+		force    bool
+		existing identifiers
+		name     string
+		expected string
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "unused name gets added unchanged",
+			existing: identifiers{},
+			name:     "UserID",
+			expected: "UserID",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "used name without force produces error",
+			existing: identifiers{"UserID": `column "user_id"`},
+			name:     "UserID",
+			expected: "",
+			isError:  assert.Error,
+		},
+		{
+			desc:     "used name with force gets suffixed",
+			force:    true,
+			existing: identifiers{"UserID": `column "user_id"`},
+			name:     "UserID",
+			expected: "UserID2",
+			isError:  assert.NoError,
+		},
+		{
+			desc:  "used name with force gets the next free suffix",
+			force: true,
+			existing: identifiers{
+				"UserID":  `column "user_id"`,
+				"UserID2": `column "UserID"`,
+			},
+			name:     "UserID",
+			expected: "UserID3",
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "reserved field name with force gets suffixed",
+			force:    true,
+			existing: identifiers{"Recorder": "structable.Recorder"},
+			name:     "Recorder",
+			expected: "Recorder2",
+			isError:  assert.NoError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.Force = test.force
+			actual, err := newGenerator(s, nil).addIdentifier(test.existing, s, test.name, `column "userId"`)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+			if err == nil {
+				assert.Equal(t, `column "userId"`, test.existing[actual])
+			}
+		})
+	}
+}
+
+func TestEscapeReserved(t *testing.T) {
+	tests := []struct {
+		desc     string
+		name     string
+		isType   bool
+		expected string
+	}{
+		{
+			desc:     "regular field name stays unchanged",
+			name:     "Type",
+			expected: "Type",
+		},
+		{
+			desc:     "keyword field name gets escaped",
+			name:     "type",
+			expected: "type_",
+		},
+		{
+			desc:     "predeclared field name stays unchanged",
+			name:     "string",
+			expected: "string",
+		},
+		{
+			desc:     "keyword type name gets escaped",
+			name:     "func",
+			isType:   true,
+			expected: "func_",
+		},
+		{
+			desc:     "predeclared type name gets escaped",
+			name:     "error",
+			isType:   true,
+			expected: "error_",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			actual := newGenerator(s, nil).escapeReserved(s, test.name, "column", test.isType)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestReservedFieldNames(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func(s *settings.Settings)
+		reserved []string
+		free     []string
+	}{
+		{
+			desc:     "plain structs reserve nothing",
+			settings: func(s *settings.Settings) {},
+			free:     []string{"Table", "Select", "TableName", "BaseModel"},
+		},
+		{
+			desc: "typed columns reserve the embedded runtime.Table and its methods",
+			settings: func(s *settings.Settings) {
+				s.GenerateTypedColumns = true
+			},
+			reserved: []string{"Table", "Select", "TableName"},
+		},
+		{
+			desc: "gorm reserves the method TableName",
+			settings: func(s *settings.Settings) {
+				s.TagsGorm = true
+			},
+			reserved: []string{"TableName"},
+			free:     []string{"Select"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			test.settings(s)
+			actual := reservedFieldNames(s)
+			for _, name := range test.reserved {
+				assert.Contains(t, actual, name)
+			}
+			for _, name := range test.free {
+				assert.NotContains(t, actual, name)
+			}
+		})
+	}
+}
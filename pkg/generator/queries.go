@@ -0,0 +1,311 @@
+package generator
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/output"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/tagger"
+)
+
+// queriesTemplate is the template of the files of the SQL files.
+//
+//go:embed queries.tmpl
+var queriesTemplate string
+
+// queryFile is the model a SQL file gets rendered with by the queries
+// template.
+type queryFile struct {
+	// Package is the name of the package of the file.
+	Package string
+	// Imports are the import paths of the standard library and null types.
+	Imports []string
+	// ExternalImports are the import paths of third party packages.
+	ExternalImports []string
+	// Path is the path of the SQL file.
+	Path string
+	// Queries are the queries of the SQL file in their order.
+	Queries []*query
+}
+
+// query is the model of the generated statement, row struct and function of
+// a query of a SQL file.
+type query struct {
+	// Name is the Go name of the function, as given by the annotation.
+	Name string
+	// QueryName is the Go name of the constant holding the statement.
+	QueryName string
+	// RowName is the Go name of the struct of a result row, empty for
+	// queries without rows.
+	RowName string
+
+	Kind   database.QueryKind
+	SQL    string
+	Params []queryParam
+	Fields []structField
+}
+
+// queryParam is a parameter of the function of a query.
+type queryParam struct {
+	Name string
+	Type string
+}
+
+// generateQueries generates a file per SQL file of the settings with typed
+// functions for its queries.
+func (g *generator) generateQueries(ctx context.Context, settings *settings.Settings, db database.Database, out output.Writer) error {
+	tmpl, err := template.New("queries").Funcs(templateFuncs).Parse(queriesTemplate)
+	if err != nil {
+		return fmt.Errorf("could not parse queries template: %w", err)
+	}
+
+	paths, err := sqlFiles(settings)
+	if err != nil {
+		return err
+	}
+
+	// Functions, constants and structs share the namespace of the package.
+	structNames, fileNames := identifiers{}, identifiers{}
+
+	for _, path := range paths {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		g.logger.Debug("processing file", "file", path)
+		g.begin("file", path)
+
+		content, err := g.createQueriesString(settings, db, tmpl, path, structNames)
+		if err != nil {
+			err = fmt.Errorf("could not create string for file %q: %w", path, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("file", path, err)
+			continue
+		}
+
+		fileName, err := g.addIdentifier(fileNames, settings, formatQueriesFileName(settings, path), fmt.Sprintf("file %q", path))
+		if err != nil {
+			err = fmt.Errorf("could not create file name for file %q: %w", path, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("file", path, err)
+			continue
+		}
+
+		if err = g.write(out, fileName, content); err != nil {
+			err = fmt.Errorf("could not write queries of file %q: %w", path, err)
+			if !settings.Force {
+				return err
+			}
+			g.skip("file", path, err)
+		}
+	}
+
+	return nil
+}
+
+// sqlFiles returns the SQL files of the settings. Directories are expanded to
+// the *.sql files they contain.
+func sqlFiles(settings *settings.Settings) ([]string, error) {
+	var paths []string
+	for _, path := range settings.SQLFiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read SQL file: %w", err)
+		}
+		if !info.IsDir() {
+			paths = append(paths, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.sql"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// formatQueriesFileName returns the name of the file for the given SQL file
+// according to the provided settings, eg. `UsersQueries` for `users.sql`.
+func formatQueriesFileName(settings *settings.Settings, path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	fileName := camelCaseString(strings.Map(replaceSpace, base)) + "Queries"
+	if settings.IsFileNameFormatSnakeCase() {
+		fileName = strcase.ToSnake(fileName)
+	}
+	return fileName
+}
+
+// createQueriesString renders the queries of the SQL file.
+func (g *generator) createQueriesString(settings *settings.Settings, db database.Database, tmpl *template.Template, path string, structNames identifiers) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read SQL file: %w", err)
+	}
+
+	queries, err := database.ParseQueries(string(content))
+	if err != nil {
+		return "", err
+	}
+
+	g.logger.Debug("found queries", "file", path, "count", len(queries))
+
+	file := queryFile{
+		Package: settings.PackageName,
+		Path:    filepath.Base(path),
+	}
+	columnInfo := columnInfo{}
+
+	for _, q := range queries {
+		model, info, err := g.newQuery(settings, db, q, structNames)
+		if err != nil {
+			err = fmt.Errorf("could not create query %q: %w", q.Name, err)
+			if !settings.Force {
+				return "", err
+			}
+			g.skip("query", q.Name, err)
+			continue
+		}
+
+		file.Queries = append(file.Queries, model)
+		for _, path := range info.imports {
+			columnInfo.addImport(path)
+		}
+	}
+
+	file.Imports, file.ExternalImports = generateQueryImports(settings, columnInfo, file)
+
+	var fileContent strings.Builder
+	if err = tmpl.Execute(&fileContent, file); err != nil {
+		return "", fmt.Errorf("could not execute template: %w", err)
+	}
+
+	return fileContent.String(), nil
+}
+
+// newQuery describes the query by the database and creates its model. The
+// parameters and result columns get the Go types of the columns of tables.
+func (g *generator) newQuery(settings *settings.Settings, db database.Database, q *database.Query, structNames identifiers) (*query, columnInfo, error) {
+	info := columnInfo{}
+
+	if !token.IsIdentifier(q.Name) {
+		return nil, info, fmt.Errorf("name is not a valid Go identifier")
+	}
+
+	if err := database.DescribeQuery(db, q); err != nil {
+		return nil, info, fmt.Errorf("could not describe query: %w", err)
+	}
+
+	model := &query{
+		Name:      q.Name,
+		QueryName: q.Name + "Query",
+		Kind:      q.Kind,
+		SQL:       q.SQL,
+	}
+	if q.Kind.HasRows() {
+		model.RowName = q.Name + "Row"
+	}
+
+	origin := fmt.Sprintf("query %q", q.Name)
+	for _, name := range []*string{&model.Name, &model.QueryName, &model.RowName} {
+		if *name == "" {
+			continue
+		}
+		var err error
+		if *name, err = g.addIdentifier(structNames, settings, *name, origin); err != nil {
+			return nil, info, err
+		}
+	}
+
+	params := map[string]bool{}
+	for _, column := range q.Params {
+		param := queryParam{Type: "any"}
+		if column.DataType == "" {
+			g.logger.Warn("type of parameter unknown; using any", "query", q.Name, "parameter", column.OrdinalPosition)
+		} else {
+			// Parameters are plain values, NULL is compared by IS NULL.
+			column.IsNullable = "NO"
+			goType := g.mapColumnType(settings, db, column)
+			info.add(goType)
+			param.Type = goType.Name
+		}
+
+		param.Name = "arg" + strconv.Itoa(column.OrdinalPosition)
+		if column.Name != "" {
+			param.Name = paramName(strcase.ToCamel(column.Name))
+		}
+		for name, i := param.Name, 2; params[param.Name]; i++ {
+			param.Name = name + strconv.Itoa(i)
+		}
+		params[param.Name] = true
+
+		model.Params = append(model.Params, param)
+	}
+
+	table := &database.Table{Name: q.Name, Columns: q.Columns}
+	fieldNames := identifiers{}
+	for _, column := range q.Columns {
+		fieldName, err := g.formatColumnName(settings, column.Name, q.Name)
+		if err != nil {
+			return nil, info, err
+		}
+		fieldName, err = g.addIdentifier(fieldNames, settings, fieldName, fmt.Sprintf("column %q of query %q", column.Name, q.Name))
+		if err != nil {
+			return nil, info, err
+		}
+
+		goType := g.mapColumnType(settings, db, column)
+		info.add(goType)
+		fieldType := goType.Name
+
+		model.Fields = append(model.Fields, structField{
+			Name: fieldName,
+			Type: fieldType,
+			Tag: g.taggers.GenerateFieldTag(db, tagger.Field{
+				Table:    table,
+				Column:   column,
+				Name:     fieldName,
+				Type:     fieldType,
+				Settings: settings,
+			}),
+			Column:     column,
+			IsNullable: db.IsNullable(column),
+		})
+	}
+
+	return model, info, nil
+}
+
+// generateQueryImports returns the import paths needed by the file of the
+// queries, separated into the ones of the standard library and the Go types
+// of the columns and the external ones.
+func generateQueryImports(settings *settings.Settings, columnInfo columnInfo, file queryFile) (imports, externalImports []string) {
+	if len(file.Queries) == 0 {
+		return nil, nil
+	}
+
+	imports = append(imports, "context")
+	imports = append(imports, columnInfo.imports...)
+	sort.Strings(imports)
+
+	externalImports = append(externalImports, "github.com/jmoiron/sqlx")
+
+	return imports, externalImports
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// repository is the model of the generated sqlx CRUD functions of a table.
+type repository struct {
+	// GetName, ListName, InsertName, UpdateName and DeleteName are the Go
+	// names of the functions. All but ListName are empty for tables without
+	// primary key.
+	GetName    string
+	ListName   string
+	InsertName string
+	UpdateName string
+	DeleteName string
+
+	// Keys are the primary key fields with their parameter names.
+	Keys []repositoryParam
+
+	// Select selects all columns of all rows.
+	Select string
+	// Get selects all columns of the row with the primary key.
+	Get string
+	// Insert inserts the InsertFields. If InsertReturning is set, the query
+	// returns the auto increment columns.
+	Insert          string
+	InsertFields    []structField
+	InsertReturning bool
+	// LastInsertID is the name of the field to set to the ID of the inserted
+	// row, if the database doesn't support returning columns.
+	LastInsertID string
+	// Update updates the UpdateFields of the row with the primary key.
+	Update       string
+	UpdateFields []structField
+	// Delete deletes the row with the primary key.
+	Delete string
+}
+
+// repositoryParam is a primary key field as parameter of a function.
+type repositoryParam struct {
+	Field structField
+	Name  string
+}
+
+// newRepository creates the model of the CRUD functions of the table of the
+// given file. Tables without primary key only get the read functions.
+func newRepository(s *settings.Settings, db database.Database, file structFile) *repository {
+	d := dialect(s)
+	table := file.Table.Name
+
+	r := &repository{
+		ListName: "List" + file.StructName,
+		Select:   d.SelectQuery(table, columnNames(file.Fields)),
+	}
+
+	if len(file.PrimaryKeys) == 0 {
+		return r
+	}
+
+	var autoIncrements []structField
+	for _, field := range file.Fields {
+		if field.IsAutoIncrement {
+			autoIncrements = append(autoIncrements, field)
+		}
+		if field.IsAutoIncrement || database.IsGenerated(db, field.Column) {
+			continue
+		}
+		r.InsertFields = append(r.InsertFields, field)
+	}
+	r.Insert = d.InsertQuery(table, columnNames(r.InsertFields), columnNames(autoIncrements))
+	r.InsertReturning = len(autoIncrements) > 0 && d.SupportsReturning()
+	if !r.InsertReturning && len(autoIncrements) == 1 && autoIncrements[0].Type == "int" {
+		r.LastInsertID = autoIncrements[0].Name
+	}
+
+	byKeys := make([]string, 0, len(file.PrimaryKeys))
+	for _, field := range file.PrimaryKeys {
+		byKeys = append(byKeys, field.Name)
+		r.Keys = append(r.Keys, repositoryParam{
+			Field: field,
+			Name:  paramName(field.Name),
+		})
+	}
+	by := "By" + strings.Join(byKeys, "And")
+
+	r.GetName = "Get" + file.StructName + by
+	r.InsertName = "Insert" + file.StructName
+	r.UpdateName = "Update" + file.StructName
+	r.DeleteName = "Delete" + file.StructName + by
+
+	keys := columnNames(file.PrimaryKeys)
+	r.Get = r.Select + " WHERE " + d.Where(keys, 1)
+	r.Delete = d.DeleteQuery(table, keys)
+
+	for _, field := range file.Fields {
+		if field.IsPrimaryKey || field.IsAutoIncrement || database.IsGenerated(db, field.Column) {
+			continue
+		}
+		r.UpdateFields = append(r.UpdateFields, field)
+	}
+	// Without columns besides the primary key there is nothing to update.
+	if len(r.UpdateFields) == 0 {
+		r.UpdateName = ""
+	} else {
+		r.Update = d.UpdateQuery(table, columnNames(r.UpdateFields), keys)
+	}
+
+	return r
+}
+
+// functionNames returns the Go names of the generated functions to resolve
+// collisions with other identifiers.
+func (r *repository) functionNames() []*string {
+	var names []*string
+	for _, name := range []*string{&r.GetName, &r.ListName, &r.InsertName, &r.UpdateName, &r.DeleteName} {
+		if *name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// columnNames returns the names of the columns of the fields.
+func columnNames(fields []structField) []string {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Column.Name)
+	}
+	return names
+}
+
+// placeholder returns the n-th bind parameter in the syntax of the database.
+func placeholder(s *settings.Settings, n int) string {
+	return dialect(s).Placeholder(n)
+}
+
+// quoteIdentifier quotes the name of a table or column in the syntax of the
+// database.
+func quoteIdentifier(s *settings.Settings, name string) string {
+	return dialect(s).Quote(name)
+}
+
+// reservedParamNames are the parameters, variables and imported packages
+// of the generated functions.
+var reservedParamNames = map[string]bool{
+	"ctx":     true,
+	"db":      true,
+	"row":     true,
+	"rows":    true,
+	"err":     true,
+	"result":  true,
+	"context": true,
+	"sqlx":    true,
+}
+
+// paramName returns the name of the parameter for the given field name,
+// which must neither be a keyword nor clash with the other parameters and
+// variables of the generated functions.
+func paramName(fieldName string) string {
+	name := strcase.ToLowerCamel(fieldName)
+	if token.IsKeyword(name) || reservedParamNames[name] {
+		name += "_"
+	}
+	return name
+}
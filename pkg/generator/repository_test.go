@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestNewRepository(t *testing.T) {
+	id := structField{
+		Name:            "ID",
+		Type:            "int",
+		Column:          database.Column{Name: "id"},
+		IsPrimaryKey:    true,
+		IsAutoIncrement: true,
+	}
+	email := structField{
+		Name:   "Email",
+		Type:   "string",
+		Column: database.Column{Name: "email"},
+	}
+	fullName := structField{
+		Name:   "FullName",
+		Type:   "string",
+		Column: database.Column{Name: "full_name", IsGenerated: "ALWAYS"},
+	}
+	// The fields of SQLite are classified by its database, with the columns
+	// as reported by its driver: only a single INTEGER primary key is an
+	// alias of the rowid and auto incremented.
+	sqlite := database.New(&settings.Settings{DbType: settings.DBTypeSQLite})
+	sqliteField := func(name, typ string, column database.Column) structField {
+		return structField{
+			Name:            name,
+			Type:            typ,
+			Column:          column,
+			IsPrimaryKey:    sqlite.IsPrimaryKey(column),
+			IsAutoIncrement: sqlite.IsAutoIncrement(column),
+		}
+	}
+	rowID := sqliteField("ID", "int", database.Column{Name: "id", DataType: "INTEGER", ColumnKey: "PK", Extra: "auto_increment"})
+	token := sqliteField("Token", "string", database.Column{Name: "token", DataType: "TEXT", ColumnKey: "PK"})
+	sqliteEmail := sqliteField("Email", "string", database.Column{Name: "email", DataType: "TEXT"})
+	orderID := sqliteField("OrderID", "int", database.Column{Name: "order_id", DataType: "INTEGER", ColumnKey: "PK"})
+	itemType := sqliteField("Type", "string", database.Column{Name: "type", DataType: "TEXT", ColumnKey: "PK"})
+
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		file     structFile
+		expected *repository
+	}{
+		{
+			desc:   "pg returns auto increment columns and skips generated columns",
+			dbType: settings.DBTypePostgresql,
+			file: structFile{
+				Table:       &database.Table{Name: "users"},
+				StructName:  "Users",
+				Fields:      []structField{id, email, fullName},
+				PrimaryKeys: []structField{id},
+			},
+			expected: &repository{
+				GetName:         "GetUsersByID",
+				ListName:        "ListUsers",
+				InsertName:      "InsertUsers",
+				UpdateName:      "UpdateUsers",
+				DeleteName:      "DeleteUsersByID",
+				Keys:            []repositoryParam{{Field: id, Name: "id"}},
+				Select:          `SELECT "id", "email", "full_name" FROM "users"`,
+				Get:             `SELECT "id", "email", "full_name" FROM "users" WHERE "id" = $1`,
+				Insert:          `INSERT INTO "users" ("email") VALUES ($1) RETURNING "id"`,
+				InsertFields:    []structField{email},
+				InsertReturning: true,
+				Update:          `UPDATE "users" SET "email" = $1 WHERE "id" = $2`,
+				UpdateFields:    []structField{email},
+				Delete:          `DELETE FROM "users" WHERE "id" = $1`,
+			},
+		},
+		{
+			desc:   "mysql sets the last insert ID",
+			dbType: settings.DBTypeMySQL,
+			file: structFile{
+				Table:       &database.Table{Name: "users"},
+				StructName:  "Users",
+				Fields:      []structField{id, email},
+				PrimaryKeys: []structField{id},
+			},
+			expected: &repository{
+				GetName:      "GetUsersByID",
+				ListName:     "ListUsers",
+				InsertName:   "InsertUsers",
+				UpdateName:   "UpdateUsers",
+				DeleteName:   "DeleteUsersByID",
+				Keys:         []repositoryParam{{Field: id, Name: "id"}},
+				Select:       "SELECT `id`, `email` FROM `users`",
+				Get:          "SELECT `id`, `email` FROM `users` WHERE `id` = ?",
+				Insert:       "INSERT INTO `users` (`email`) VALUES (?)",
+				InsertFields: []structField{email},
+				LastInsertID: "ID",
+				Update:       "UPDATE `users` SET `email` = ? WHERE `id` = ?",
+				UpdateFields: []structField{email},
+				Delete:       "DELETE FROM `users` WHERE `id` = ?",
+			},
+		},
+		{
+			desc:   "mssql outputs auto increment columns",
+			dbType: settings.DBTypeMsSQL,
+			file: structFile{
+				Table:       &database.Table{Name: "users"},
+				StructName:  "Users",
+				Fields:      []structField{id, email},
+				PrimaryKeys: []structField{id},
+			},
+			expected: &repository{
+				GetName:         "GetUsersByID",
+				ListName:        "ListUsers",
+				InsertName:      "InsertUsers",
+				UpdateName:      "UpdateUsers",
+				DeleteName:      "DeleteUsersByID",
+				Keys:            []repositoryParam{{Field: id, Name: "id"}},
+				Select:          "SELECT [id], [email] FROM [users]",
+				Get:             "SELECT [id], [email] FROM [users] WHERE [id] = @p1",
+				Insert:          "INSERT INTO [users] ([email]) OUTPUT INSERTED.[id] VALUES (@p1)",
+				InsertFields:    []structField{email},
+				InsertReturning: true,
+				Update:          "UPDATE [users] SET [email] = @p1 WHERE [id] = @p2",
+				UpdateFields:    []structField{email},
+				Delete:          "DELETE FROM [users] WHERE [id] = @p1",
+			},
+		},
+		{
+			desc:   "sqlite sets the last insert ID of the rowid",
+			dbType: settings.DBTypeSQLite,
+			file: structFile{
+				Table:       &database.Table{Name: "users"},
+				StructName:  "Users",
+				Fields:      []structField{rowID, sqliteEmail},
+				PrimaryKeys: []structField{rowID},
+			},
+			expected: &repository{
+				GetName:      "GetUsersByID",
+				ListName:     "ListUsers",
+				InsertName:   "InsertUsers",
+				UpdateName:   "UpdateUsers",
+				DeleteName:   "DeleteUsersByID",
+				Keys:         []repositoryParam{{Field: rowID, Name: "id"}},
+				Select:       `SELECT "id", "email" FROM "users"`,
+				Get:          `SELECT "id", "email" FROM "users" WHERE "id" = ?`,
+				Insert:       `INSERT INTO "users" ("email") VALUES (?)`,
+				InsertFields: []structField{sqliteEmail},
+				LastInsertID: "ID",
+				Update:       `UPDATE "users" SET "email" = ? WHERE "id" = ?`,
+				UpdateFields: []structField{sqliteEmail},
+				Delete:       `DELETE FROM "users" WHERE "id" = ?`,
+			},
+		},
+		{
+			desc:   "sqlite inserts a text primary key",
+			dbType: settings.DBTypeSQLite,
+			file: structFile{
+				Table:       &database.Table{Name: "sessions"},
+				StructName:  "Sessions",
+				Fields:      []structField{token, sqliteEmail},
+				PrimaryKeys: []structField{token},
+			},
+			expected: &repository{
+				GetName:      "GetSessionsByToken",
+				ListName:     "ListSessions",
+				InsertName:   "InsertSessions",
+				UpdateName:   "UpdateSessions",
+				DeleteName:   "DeleteSessionsByToken",
+				Keys:         []repositoryParam{{Field: token, Name: "token"}},
+				Select:       `SELECT "token", "email" FROM "sessions"`,
+				Get:          `SELECT "token", "email" FROM "sessions" WHERE "token" = ?`,
+				Insert:       `INSERT INTO "sessions" ("token", "email") VALUES (?, ?)`,
+				InsertFields: []structField{token, sqliteEmail},
+				Update:       `UPDATE "sessions" SET "email" = ? WHERE "token" = ?`,
+				UpdateFields: []structField{sqliteEmail},
+				Delete:       `DELETE FROM "sessions" WHERE "token" = ?`,
+			},
+		},
+		{
+			desc:   "composite primary key without other columns has no update",
+			dbType: settings.DBTypeSQLite,
+			file: structFile{
+				Table:       &database.Table{Name: "order_items"},
+				StructName:  "OrderItems",
+				Fields:      []structField{orderID, itemType},
+				PrimaryKeys: []structField{orderID, itemType},
+			},
+			expected: &repository{
+				GetName:    "GetOrderItemsByOrderIDAndType",
+				ListName:   "ListOrderItems",
+				InsertName: "InsertOrderItems",
+				DeleteName: "DeleteOrderItemsByOrderIDAndType",
+				Keys: []repositoryParam{
+					{Field: orderID, Name: "orderID"},
+					{Field: itemType, Name: "type_"},
+				},
+				Select:       `SELECT "order_id", "type" FROM "order_items"`,
+				Get:          `SELECT "order_id", "type" FROM "order_items" WHERE "order_id" = ? AND "type" = ?`,
+				Insert:       `INSERT INTO "order_items" ("order_id", "type") VALUES (?, ?)`,
+				InsertFields: []structField{orderID, itemType},
+				Delete:       `DELETE FROM "order_items" WHERE "order_id" = ? AND "type" = ?`,
+			},
+		},
+		{
+			desc:   "table without primary key gets only read functions",
+			dbType: settings.DBTypePostgresql,
+			file: structFile{
+				Table:      &database.Table{Name: "logs"},
+				StructName: "Logs",
+				Fields:     []structField{email},
+			},
+			expected: &repository{
+				ListName: "ListLogs",
+				Select:   `SELECT "email" FROM "logs"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			actual := newRepository(s, database.New(s), test.file)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := []struct {
+		desc      string
+		fieldName string
+		expected  string
+	}{
+		{
+			desc:      "field name becomes lower camel case",
+			fieldName: "OrderID",
+			expected:  "orderID",
+		},
+		{
+			desc:      "keyword gets escaped",
+			fieldName: "Type",
+			expected:  "type_",
+		},
+		{
+			desc:      "parameter of the generated functions gets escaped",
+			fieldName: "Ctx",
+			expected:  "ctx_",
+		},
+		{
+			desc:      "variable of the generated functions gets escaped",
+			fieldName: "Rows",
+			expected:  "rows_",
+		},
+		{
+			desc:      "imported package of the generated functions gets escaped",
+			fieldName: "Sqlx",
+			expected:  "sqlx_",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := paramName(test.fieldName)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"errors"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// schema is a Database serving the tables of a schema model instead of
+// reading them from a connection. The columns are classified by the database
+// of the database type of the settings, which never gets connected.
+type schema struct {
+	database.Database
+
+	tables []*database.Table
+}
+
+// newSchema creates the Database of the given tables.
+func newSchema(s *settings.Settings, tables []*database.Table) *schema {
+	return &schema{
+		Database: database.New(s),
+		tables:   tables,
+	}
+}
+
+// GetTables returns the tables of the schema.
+func (s *schema) GetTables() ([]*database.Table, error) {
+	return s.tables, nil
+}
+
+// GetViews returns no views, as views are given as tables.
+func (s *schema) GetViews() ([]*database.Table, error) {
+	return nil, nil
+}
+
+// PrepareGetColumnsOfTableStmt does nothing, as the columns are given.
+func (s *schema) PrepareGetColumnsOfTableStmt() error {
+	return nil
+}
+
+// PrepareGetColumnsOfViewStmt does nothing, as the columns are given.
+func (s *schema) PrepareGetColumnsOfViewStmt() error {
+	return nil
+}
+
+// GetColumnsOfTable does nothing, as the columns are given.
+func (s *schema) GetColumnsOfTable(_ *database.Table) error {
+	return nil
+}
+
+// GetColumnsOfView does nothing, as the columns are given.
+func (s *schema) GetColumnsOfView(_ *database.Table) error {
+	return nil
+}
+
+// DescribeQuery returns an error, as queries can only be described by a
+// database.
+func (s *schema) DescribeQuery(_ *database.Query) error {
+	return errors.New("queries can't be described without a database")
+}
+
+// IsUnique checks with the database of the database type if the column has a
+// unique constraint.
+func (s *schema) IsUnique(column database.Column) bool {
+	return database.IsUnique(s.Database, column)
+}
+
+// IsGenerated checks with the database of the database type if the column is
+// a generated column.
+func (s *schema) IsGenerated(column database.Column) bool {
+	return database.IsGenerated(s.Database, column)
+}
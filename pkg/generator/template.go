@@ -0,0 +1,174 @@
+package generator
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iancoleman/strcase"
+	"github.com/jinzhu/inflection"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/tagger"
+)
+
+// defaultTemplate is the template of the built-in output.
+//
+//go:embed struct.tmpl
+var defaultTemplate string
+
+// repositoryTemplate defines the template "repository" of the CRUD functions,
+// which is available in custom templates as well.
+//
+//go:embed repository.tmpl
+var repositoryTemplate string
+
+// upsertTemplate defines the template "upsert" of the upsert query and
+// function, which is available in custom templates as well.
+//
+//go:embed upsert.tmpl
+var upsertTemplate string
+
+// descriptorTemplate defines the template "descriptor" of the table
+// descriptor for runtime.Repository, which is available in custom templates
+// as well.
+//
+//go:embed descriptor.tmpl
+var descriptorTemplate string
+
+// typedColumnsTemplate defines the template "typedColumns" of the typed
+// columns for the query builder, which is available in custom templates as
+// well.
+//
+//go:embed typedcolumns.tmpl
+var typedColumnsTemplate string
+
+// templateFuncs are the helper functions available in struct templates.
+var templateFuncs = template.FuncMap{
+	"snake":       strcase.ToSnake,
+	"camel":       strcase.ToCamel,
+	"lowerCamel":  strcase.ToLowerCamel,
+	"kebab":       strcase.ToKebab,
+	"upper":       strings.ToUpper,
+	"lower":       strings.ToLower,
+	"pluralize":   inflection.Plural,
+	"singularize": inflection.Singular,
+	"quote":       strconv.Quote,
+	"join":        strings.Join,
+	"comment":     comment,
+	"bunTable":    tagger.BunTableTag,
+}
+
+// structFile is the model a table gets rendered with by the struct template.
+type structFile struct {
+	// Package is the name of the package of the file.
+	Package string
+	// Imports are the import paths of the standard library and null types.
+	Imports []string
+	// ExternalImports are the import paths of third party packages.
+	ExternalImports []string
+	// Table is the table the struct originates from.
+	Table *database.Table
+	// StructName is the Go name of the struct.
+	StructName string
+	// ReceiverName is the name of the receiver of the methods of the struct.
+	ReceiverName string
+	// ColumnsName is the Go name of the variable holding the column names.
+	ColumnsName string
+	// AllColumnsName is the Go name of the variable holding the ordered
+	// column names.
+	AllColumnsName string
+	// Fields are the fields of the struct in the order of the columns.
+	Fields []structField
+	// PrimaryKeys are the fields of the primary key columns.
+	PrimaryKeys []structField
+	// Repository are the CRUD functions of the table, if enabled.
+	Repository *repository
+	// Upsert is the upsert query and function of the table, if enabled and
+	// the table has a primary key or unique column.
+	Upsert *upsert
+	// Descriptor is the table descriptor for runtime.Repository, if enabled.
+	Descriptor *descriptor
+	// TypedColumns are the typed columns of the table for the query builder,
+	// if enabled.
+	TypedColumns *typedColumns
+	// Settings are the settings the file gets generated with.
+	Settings *settings.Settings
+}
+
+// structField is a field of the struct in the struct template.
+type structField struct {
+	Name            string
+	Type            string
+	Tag             string
+	Comment         string
+	Column          database.Column
+	IsPrimaryKey    bool
+	IsAutoIncrement bool
+	IsNullable      bool
+}
+
+// newStructTemplate parses the template file of the settings or the default
+// template if none is given.
+func newStructTemplate(settings *settings.Settings) (*template.Template, error) {
+	text := defaultTemplate
+	if settings.Template != "" {
+		content, err := os.ReadFile(settings.Template)
+		if err != nil {
+			return nil, fmt.Errorf("could not read template: %w", err)
+		}
+		text = string(content)
+	}
+
+	tmpl, err := template.New("struct").Funcs(templateFuncs).Parse(repositoryTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse repository template: %w", err)
+	}
+
+	tmpl, err = tmpl.Parse(upsertTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse upsert template: %w", err)
+	}
+
+	tmpl, err = tmpl.Parse(descriptorTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse descriptor template: %w", err)
+	}
+
+	tmpl, err = tmpl.Parse(typedColumnsTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse typed columns template: %w", err)
+	}
+
+	tmpl, err = tmpl.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// receiverName returns the name of the receiver of the methods of the given
+// struct, which is its lower-cased first letter.
+func receiverName(structName string) string {
+	r, _ := utf8.DecodeRuneInString(structName)
+	if !unicode.IsLetter(r) {
+		return "t"
+	}
+	return string(unicode.ToLower(r))
+}
+
+// comment formats s as line comment, eg. for multi-line column comments.
+func comment(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace("// " + strings.TrimSpace(line))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiverName(t *testing.T) {
+	tests := []struct {
+		desc       string
+		structName string
+		expected   string
+	}{
+		{
+			desc:       "first letter gets lower-cased",
+			structName: "Users",
+			expected:   "u",
+		},
+		{
+			desc:       "first non-ASCII letter gets lower-cased",
+			structName: "Übersicht",
+			expected:   "ü",
+		},
+		{
+			desc:       "no letter falls back to default",
+			structName: "_users",
+			expected:   "t",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := receiverName(test.structName)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestComment(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{
+			desc:     "single line becomes line comment",
+			input:    "identifier of the user",
+			expected: "// identifier of the user",
+		},
+		{
+			desc:     "multiple lines become multiple line comments",
+			input:    "identifier\n\nof the user\n",
+			expected: "// identifier\n//\n// of the user",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := comment(test.input)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
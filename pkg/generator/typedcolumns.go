@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/typemapper"
+)
+
+// typedColumns is the model of the generated typed columns of a table for
+// the query builder of the runtime package.
+type typedColumns struct {
+	// Name is the Go name of the variable holding the typed columns.
+	Name string
+	// Dialect is the Go name of the runtime dialect of the database.
+	Dialect string
+	// Columns are the typed columns in the order of the fields.
+	Columns []typedColumn
+}
+
+// typedColumn is a field with the Go name of its runtime column type, eg.
+// IntColumn.
+type typedColumn struct {
+	Field structField
+	Type  string
+}
+
+// newTypedColumns creates the model of the typed columns of the table of the
+// given file.
+func (g *generator) newTypedColumns(s *settings.Settings, db database.Database, file structFile) *typedColumns {
+	t := &typedColumns{
+		Name:    file.StructName + "Table",
+		Dialect: dialectNames[s.SQLDialect()],
+	}
+	for _, field := range file.Fields {
+		t.Columns = append(t.Columns, typedColumn{
+			Field: field,
+			Type:  g.columnType(s, db, field.Column),
+		})
+	}
+	return t
+}
+
+// columnType returns the Go name of the runtime column type of the column,
+// following the kind of the Go type of the field.
+func (g *generator) columnType(s *settings.Settings, db database.Database, column database.Column) string {
+	switch g.mapColumnType(s, db, column).Kind {
+	case typemapper.KindInteger:
+		return "IntColumn"
+	case typemapper.KindFloat:
+		return "FloatColumn"
+	case typemapper.KindTemporal:
+		return "TimeColumn"
+	case typemapper.KindBool:
+		return "BoolColumn"
+	default:
+		return "StringColumn"
+	}
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// upsert is the model of the generated upsert query and function of a table.
+type upsert struct {
+	// QueryName and FuncName are the Go names of the constant holding the
+	// query and of the function executing it.
+	QueryName string
+	FuncName  string
+
+	// Query inserts the Fields or updates the existing row on a conflict on
+	// the primary key or unique column.
+	Query  string
+	Fields []structField
+}
+
+// newUpsert creates the model of the upsert of the table of the given file.
+// The conflict target is the primary key or else a unique column. Tables
+// without either get no upsert and nil is returned.
+func newUpsert(s *settings.Settings, db database.Database, file structFile) *upsert {
+	keys := file.PrimaryKeys
+	if len(keys) == 0 {
+		for _, field := range file.Fields {
+			if database.IsUnique(db, field.Column) {
+				keys = []structField{field}
+				break
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	isKey := map[string]bool{}
+	for _, field := range keys {
+		isKey[field.Column.Name] = true
+	}
+
+	// The excluded columns neither get inserted nor updated, so they keep
+	// their default or current value.
+	var fields, updates []structField
+	for _, field := range file.Fields {
+		if database.IsGenerated(db, field.Column) {
+			continue
+		}
+		if isKey[field.Column.Name] {
+			fields = append(fields, field)
+			continue
+		}
+		if s.IsUpsertExcluded(file.Table.Name, field.Column.Name) {
+			continue
+		}
+		fields = append(fields, field)
+		updates = append(updates, field)
+	}
+
+	return &upsert{
+		QueryName: "Upsert" + file.StructName + "Query",
+		FuncName:  "Upsert" + file.StructName,
+		Query:     upsertQuery(s, quoteIdentifier(s, file.Table.Name), fields, keys, updates),
+		Fields:    fields,
+	}
+}
+
+// names returns the Go names of the generated identifiers to resolve
+// collisions with other identifiers.
+func (u *upsert) names() []*string {
+	return []*string{&u.QueryName, &u.FuncName}
+}
+
+// upsertQuery returns the query to insert the fields into the table or to
+// update the given columns on a conflict on the keys in the syntax of the
+// database.
+func upsertQuery(s *settings.Settings, table string, fields, keys, updates []structField) string {
+	columns := make([]string, 0, len(fields))
+	values := make([]string, 0, len(fields))
+	for i, field := range fields {
+		columns = append(columns, quoteIdentifier(s, field.Column.Name))
+		values = append(values, placeholder(s, i+1))
+	}
+
+	keyColumns := make([]string, 0, len(keys))
+	for _, field := range keys {
+		keyColumns = append(keyColumns, quoteIdentifier(s, field.Column.Name))
+	}
+
+	updateColumns := make([]string, 0, len(updates))
+	for _, field := range updates {
+		updateColumns = append(updateColumns, quoteIdentifier(s, field.Column.Name))
+	}
+
+	switch s.SQLDialect() {
+	case settings.DBTypeMySQL:
+		sets := make([]string, 0, len(updateColumns))
+		for _, column := range updateColumns {
+			sets = append(sets, column+" = VALUES("+column+")")
+		}
+		// MySQL has no DO NOTHING, assigning a key to itself is a no-op.
+		if len(sets) == 0 {
+			sets = append(sets, keyColumns[0]+" = "+keyColumns[0])
+		}
+		return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")" +
+			" ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+
+	case settings.DBTypeMsSQL:
+		conditions := make([]string, 0, len(keyColumns))
+		for _, column := range keyColumns {
+			conditions = append(conditions, "t."+column+" = s."+column)
+		}
+		sets := make([]string, 0, len(updateColumns))
+		for _, column := range updateColumns {
+			sets = append(sets, "t."+column+" = s."+column)
+		}
+		// Identity columns can't be inserted, they only identify the row to
+		// update.
+		inserts := make([]string, 0, len(columns))
+		sources := make([]string, 0, len(columns))
+		for i, column := range columns {
+			if fields[i].IsAutoIncrement {
+				continue
+			}
+			inserts = append(inserts, column)
+			sources = append(sources, "s."+column)
+		}
+		query := "MERGE INTO " + table + " WITH (HOLDLOCK) AS t" +
+			" USING (VALUES (" + strings.Join(values, ", ") + ")) AS s (" + strings.Join(columns, ", ") + ")" +
+			" ON " + strings.Join(conditions, " AND ")
+		if len(sets) > 0 {
+			query += " WHEN MATCHED THEN UPDATE SET " + strings.Join(sets, ", ")
+		}
+		if len(inserts) == 0 {
+			return query + " WHEN NOT MATCHED THEN INSERT DEFAULT VALUES;"
+		}
+		return query + " WHEN NOT MATCHED THEN INSERT (" + strings.Join(inserts, ", ") + ")" +
+			" VALUES (" + strings.Join(sources, ", ") + ");"
+	}
+
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")" +
+		" ON CONFLICT (" + strings.Join(keyColumns, ", ") + ")"
+	if len(updateColumns) == 0 {
+		return query + " DO NOTHING"
+	}
+	sets := make([]string, 0, len(updateColumns))
+	for _, column := range updateColumns {
+		sets = append(sets, column+" = excluded."+column)
+	}
+	return query + " DO UPDATE SET " + strings.Join(sets, ", ")
+}
@@ -0,0 +1,230 @@
+package generator
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestNewUpsert(t *testing.T) {
+	id := structField{
+		Name:         "ID",
+		Type:         "int",
+		Column:       database.Column{Name: "id"},
+		IsPrimaryKey: true,
+	}
+	email := structField{
+		Name:   "Email",
+		Type:   "string",
+		Column: database.Column{Name: "email"},
+	}
+	identity := structField{
+		Name:            "ID",
+		Type:            "int",
+		Column:          database.Column{Name: "id"},
+		IsPrimaryKey:    true,
+		IsAutoIncrement: true,
+	}
+	createdAt := structField{
+		Name:   "CreatedAt",
+		Type:   "time.Time",
+		Column: database.Column{Name: "created_at"},
+	}
+	uniqueEmail := structField{
+		Name: "Email",
+		Type: "string",
+		Column: database.Column{
+			Name:           "email",
+			ConstraintType: sql.NullString{String: "UNIQUE", Valid: true},
+		},
+	}
+	users := structFile{
+		Table:       &database.Table{Name: "users"},
+		StructName:  "Users",
+		Fields:      []structField{id, email, createdAt},
+		PrimaryKeys: []structField{id},
+	}
+
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		dialect  settings.Dialect
+		exclude  settings.StringList
+		file     structFile
+		expected *upsert
+	}{
+		{
+			desc:    "pg updates on conflict on the primary key",
+			dbType:  settings.DBTypePostgresql,
+			exclude: settings.StringList{"created_at"},
+			file:    users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: `INSERT INTO "users" ("id", "email") VALUES ($1, $2)` +
+					` ON CONFLICT ("id") DO UPDATE SET "email" = excluded."email"`,
+				Fields: []structField{id, email},
+			},
+		},
+		{
+			desc:   "sqlite updates on conflict on the primary key",
+			dbType: settings.DBTypeSQLite,
+			file:   users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: `INSERT INTO "users" ("id", "email", "created_at") VALUES (?, ?, ?)` +
+					` ON CONFLICT ("id") DO UPDATE SET "email" = excluded."email", "created_at" = excluded."created_at"`,
+				Fields: []structField{id, email, createdAt},
+			},
+		},
+		{
+			desc:    "mysql updates on duplicate key",
+			dbType:  settings.DBTypeMySQL,
+			exclude: settings.StringList{"users.created_at"},
+			file:    users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: "INSERT INTO `users` (`id`, `email`) VALUES (?, ?)" +
+					" ON DUPLICATE KEY UPDATE `email` = VALUES(`email`)",
+				Fields: []structField{id, email},
+			},
+		},
+		{
+			desc:    "mssql merges",
+			dbType:  settings.DBTypeMsSQL,
+			exclude: settings.StringList{"created_at"},
+			file:    users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: "MERGE INTO [users] WITH (HOLDLOCK) AS t" +
+					" USING (VALUES (@p1, @p2)) AS s ([id], [email])" +
+					" ON t.[id] = s.[id]" +
+					" WHEN MATCHED THEN UPDATE SET t.[email] = s.[email]" +
+					" WHEN NOT MATCHED THEN INSERT ([id], [email]) VALUES (s.[id], s.[email]);",
+				Fields: []structField{id, email},
+			},
+		},
+		{
+			desc:    "mssql merges without inserting the identity column",
+			dbType:  settings.DBTypeMsSQL,
+			exclude: settings.StringList{"created_at"},
+			file: structFile{
+				Table:       &database.Table{Name: "users"},
+				StructName:  "Users",
+				Fields:      []structField{identity, email, createdAt},
+				PrimaryKeys: []structField{identity},
+			},
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: "MERGE INTO [users] WITH (HOLDLOCK) AS t" +
+					" USING (VALUES (@p1, @p2)) AS s ([id], [email])" +
+					" ON t.[id] = s.[id]" +
+					" WHEN MATCHED THEN UPDATE SET t.[email] = s.[email]" +
+					" WHEN NOT MATCHED THEN INSERT ([email]) VALUES (s.[email]);",
+				Fields: []structField{identity, email},
+			},
+		},
+		{
+			desc:    "mssql inserts default values with only the identity column",
+			dbType:  settings.DBTypeMsSQL,
+			exclude: settings.StringList{"email", "created_at"},
+			file: structFile{
+				Table:       &database.Table{Name: "users"},
+				StructName:  "Users",
+				Fields:      []structField{identity, email, createdAt},
+				PrimaryKeys: []structField{identity},
+			},
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: "MERGE INTO [users] WITH (HOLDLOCK) AS t" +
+					" USING (VALUES (@p1)) AS s ([id])" +
+					" ON t.[id] = s.[id]" +
+					" WHEN NOT MATCHED THEN INSERT DEFAULT VALUES;",
+				Fields: []structField{identity},
+			},
+		},
+		{
+			desc:    "generic database updates on duplicate key in the dialect of mysql",
+			dbType:  settings.DBTypeGeneric,
+			dialect: settings.Dialect(settings.DBTypeMySQL),
+			exclude: settings.StringList{"created_at"},
+			file:    users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: "INSERT INTO `users` (`id`, `email`) VALUES (?, ?)" +
+					" ON DUPLICATE KEY UPDATE `email` = VALUES(`email`)",
+				Fields: []structField{id, email},
+			},
+		},
+		{
+			desc:    "pg does nothing on conflict without columns to update",
+			dbType:  settings.DBTypePostgresql,
+			exclude: settings.StringList{"email", "created_at"},
+			file:    users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query:     `INSERT INTO "users" ("id") VALUES ($1) ON CONFLICT ("id") DO NOTHING`,
+				Fields:    []structField{id},
+			},
+		},
+		{
+			desc:    "mysql assigns the key without columns to update",
+			dbType:  settings.DBTypeMySQL,
+			exclude: settings.StringList{"email", "created_at"},
+			file:    users,
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query:     "INSERT INTO `users` (`id`) VALUES (?) ON DUPLICATE KEY UPDATE `id` = `id`",
+				Fields:    []structField{id},
+			},
+		},
+		{
+			desc:   "pg without primary key updates on conflict on the unique column",
+			dbType: settings.DBTypePostgresql,
+			file: structFile{
+				Table:      &database.Table{Name: "users"},
+				StructName: "Users",
+				Fields:     []structField{uniqueEmail, createdAt},
+			},
+			expected: &upsert{
+				QueryName: "UpsertUsersQuery",
+				FuncName:  "UpsertUsers",
+				Query: `INSERT INTO "users" ("email", "created_at") VALUES ($1, $2)` +
+					` ON CONFLICT ("email") DO UPDATE SET "created_at" = excluded."created_at"`,
+				Fields: []structField{uniqueEmail, createdAt},
+			},
+		},
+		{
+			desc:   "table without primary key or unique column gets no upsert",
+			dbType: settings.DBTypePostgresql,
+			file: structFile{
+				Table:      &database.Table{Name: "logs"},
+				StructName: "Logs",
+				Fields:     []structField{email, createdAt},
+			},
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			s.Dialect = test.dialect
+			s.UpsertExclude = test.exclude
+			actual := newUpsert(s, database.New(s), test.file)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -35,7 +35,7 @@ func NewFileWriter(path string) *FileWriter {
 // Write is the implementation of the Writer interface. The FilerWriter writes
 // decorated content to the file specified by the given path and table name.
 func (w FileWriter) Write(tableName string, content string) error {
-	fileName := path.Join(w.path, tableName+FileWriterExtension)
+	fileName := w.Path(tableName)
 
 	decorated, err := w.decorate(content)
 	if err != nil {
@@ -45,6 +45,12 @@ func (w FileWriter) Write(tableName string, content string) error {
 	return os.WriteFile(fileName, []byte(decorated), 0666)
 }
 
+// Path returns the path of the file the content of the table name gets
+// written to.
+func (w FileWriter) Path(tableName string) string {
+	return path.Join(w.path, tableName+FileWriterExtension)
+}
+
 // decorate applies some decorations like formatting and empty import removal.
 func (w FileWriter) decorate(content string) (decorated string, err error) {
 	for _, decorator := range w.decorators {
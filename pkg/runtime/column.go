@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"time"
+)
+
+// column is a column of a table whose values are of type V.
+type column[V any] struct {
+	name string
+}
+
+// Name returns the name of the column.
+func (c column[V]) Name() string {
+	return c.name
+}
+
+// build writes the quoted name of the column.
+func (c column[V]) build(b *builder) {
+	b.write(b.dialect.Quote(c.name))
+}
+
+// Eq matches the rows where the column equals value.
+func (c column[V]) Eq(value V) Condition {
+	return comparison{column: c, operator: "=", value: value}
+}
+
+// Ne matches the rows where the column doesn't equal value.
+func (c column[V]) Ne(value V) Condition {
+	return comparison{column: c, operator: "<>", value: value}
+}
+
+// In matches the rows where the column equals one of the values. Without
+// values no row matches.
+func (c column[V]) In(values ...V) Condition {
+	args := make([]any, 0, len(values))
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return in{column: c, values: args}
+}
+
+// IsNull matches the rows where the column is NULL.
+func (c column[V]) IsNull() Condition {
+	return null{column: c}
+}
+
+// IsNotNull matches the rows where the column is not NULL.
+func (c column[V]) IsNotNull() Condition {
+	return null{column: c, not: true}
+}
+
+// Asc orders the rows by the column in ascending order.
+func (c column[V]) Asc() Order {
+	return Order{column: c}
+}
+
+// Desc orders the rows by the column in descending order.
+func (c column[V]) Desc() Order {
+	return Order{column: c, desc: true}
+}
+
+// orderedColumn is a column whose values can be compared by their order.
+type orderedColumn[V any] struct {
+	column[V]
+}
+
+// Lt matches the rows where the column is less than value.
+func (c orderedColumn[V]) Lt(value V) Condition {
+	return comparison{column: c, operator: "<", value: value}
+}
+
+// Lte matches the rows where the column is less than or equal to value.
+func (c orderedColumn[V]) Lte(value V) Condition {
+	return comparison{column: c, operator: "<=", value: value}
+}
+
+// Gt matches the rows where the column is greater than value.
+func (c orderedColumn[V]) Gt(value V) Condition {
+	return comparison{column: c, operator: ">", value: value}
+}
+
+// Gte matches the rows where the column is greater than or equal to value.
+func (c orderedColumn[V]) Gte(value V) Condition {
+	return comparison{column: c, operator: ">=", value: value}
+}
+
+// StringColumn is a column of character or any other not further classified
+// data type.
+type StringColumn struct {
+	orderedColumn[string]
+}
+
+// NewStringColumn creates the StringColumn with the given name.
+func NewStringColumn(name string) StringColumn {
+	return StringColumn{orderedColumn[string]{column[string]{name: name}}}
+}
+
+// Like matches the rows where the column matches the pattern.
+func (c StringColumn) Like(pattern string) Condition {
+	return comparison{column: c, operator: "LIKE", value: pattern}
+}
+
+// IntColumn is a column of an integer data type.
+type IntColumn struct {
+	orderedColumn[int]
+}
+
+// NewIntColumn creates the IntColumn with the given name.
+func NewIntColumn(name string) IntColumn {
+	return IntColumn{orderedColumn[int]{column[int]{name: name}}}
+}
+
+// FloatColumn is a column of a floating point data type.
+type FloatColumn struct {
+	orderedColumn[float64]
+}
+
+// NewFloatColumn creates the FloatColumn with the given name.
+func NewFloatColumn(name string) FloatColumn {
+	return FloatColumn{orderedColumn[float64]{column[float64]{name: name}}}
+}
+
+// TimeColumn is a column of a date or time data type.
+type TimeColumn struct {
+	orderedColumn[time.Time]
+}
+
+// NewTimeColumn creates the TimeColumn with the given name.
+func NewTimeColumn(name string) TimeColumn {
+	return TimeColumn{orderedColumn[time.Time]{column[time.Time]{name: name}}}
+}
+
+// BoolColumn is a column of the boolean data type.
+type BoolColumn struct {
+	column[bool]
+}
+
+// NewBoolColumn creates the BoolColumn with the given name.
+func NewBoolColumn(name string) BoolColumn {
+	return BoolColumn{column[bool]{name: name}}
+}
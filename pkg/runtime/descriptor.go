@@ -0,0 +1,77 @@
+package runtime
+
+// Descriptor describes the table of a struct.
+type Descriptor struct {
+	// Table is the name of the table.
+	Table string
+	// Columns are the names of all columns in their order.
+	Columns []string
+	// PrimaryKey are the names of the primary key columns.
+	PrimaryKey []string
+	// AutoIncrement are the names of the auto increment columns, which get
+	// set by the database on insert.
+	AutoIncrement []string
+	// Generated are the names of the generated columns, which can neither be
+	// inserted nor updated.
+	Generated []string
+	// Dialect is the dialect of the database of the table.
+	Dialect Dialect
+}
+
+// selectQuery returns the query selecting all columns of all rows.
+func (d Descriptor) selectQuery() string {
+	return d.Dialect.SelectQuery(d.Table, d.Columns)
+}
+
+// getQuery returns the query selecting all columns of the row with the
+// primary key.
+func (d Descriptor) getQuery() string {
+	return d.selectQuery() + " WHERE " + d.Dialect.Where(d.PrimaryKey, 1)
+}
+
+// insertQuery returns the query inserting the given columns. The auto
+// increment columns get returned if the dialect supports it.
+func (d Descriptor) insertQuery(columns []string) string {
+	return d.Dialect.InsertQuery(d.Table, columns, d.AutoIncrement)
+}
+
+// updateQuery returns the query updating the given columns of the row with
+// the primary key.
+func (d Descriptor) updateQuery(columns []string) string {
+	return d.Dialect.UpdateQuery(d.Table, columns, d.PrimaryKey)
+}
+
+// deleteQuery returns the query deleting the row with the primary key.
+func (d Descriptor) deleteQuery() string {
+	return d.Dialect.DeleteQuery(d.Table, d.PrimaryKey)
+}
+
+// insertColumns returns the columns to insert, which are all but the auto
+// increment and generated ones.
+func (d Descriptor) insertColumns() []string {
+	return d.without(d.AutoIncrement, d.Generated)
+}
+
+// updateColumns returns the columns to update, which are all but the primary
+// key, auto increment and generated ones.
+func (d Descriptor) updateColumns() []string {
+	return d.without(d.PrimaryKey, d.AutoIncrement, d.Generated)
+}
+
+// without returns the columns without the given ones.
+func (d Descriptor) without(excluded ...[]string) []string {
+	skip := map[string]bool{}
+	for _, columns := range excluded {
+		for _, column := range columns {
+			skip[column] = true
+		}
+	}
+
+	columns := make([]string, 0, len(d.Columns))
+	for _, column := range d.Columns {
+		if !skip[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
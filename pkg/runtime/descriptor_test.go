@@ -0,0 +1,165 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescriptor_Queries(t *testing.T) {
+	users := Descriptor{
+		Table:         "users",
+		Columns:       []string{"id", "email", "full_name"},
+		PrimaryKey:    []string{"id"},
+		AutoIncrement: []string{"id"},
+		Generated:     []string{"full_name"},
+	}
+
+	type queries struct {
+		Select string
+		Get    string
+		Insert string
+		Update string
+		Delete string
+	}
+
+	tests := []struct {
+		desc     string
+		dialect  Dialect
+		expected queries
+	}{
+		{
+			desc:    "pg returns the auto increment columns",
+			dialect: DialectPostgresql,
+			expected: queries{
+				Select: `SELECT "id", "email", "full_name" FROM "users"`,
+				Get:    `SELECT "id", "email", "full_name" FROM "users" WHERE "id" = $1`,
+				Insert: `INSERT INTO "users" ("email") VALUES ($1) RETURNING "id"`,
+				Update: `UPDATE "users" SET "email" = $1 WHERE "id" = $2`,
+				Delete: `DELETE FROM "users" WHERE "id" = $1`,
+			},
+		},
+		{
+			desc:    "mysql",
+			dialect: DialectMySQL,
+			expected: queries{
+				Select: "SELECT `id`, `email`, `full_name` FROM `users`",
+				Get:    "SELECT `id`, `email`, `full_name` FROM `users` WHERE `id` = ?",
+				Insert: "INSERT INTO `users` (`email`) VALUES (?)",
+				Update: "UPDATE `users` SET `email` = ? WHERE `id` = ?",
+				Delete: "DELETE FROM `users` WHERE `id` = ?",
+			},
+		},
+		{
+			desc:    "sqlite",
+			dialect: DialectSQLite,
+			expected: queries{
+				Select: `SELECT "id", "email", "full_name" FROM "users"`,
+				Get:    `SELECT "id", "email", "full_name" FROM "users" WHERE "id" = ?`,
+				Insert: `INSERT INTO "users" ("email") VALUES (?)`,
+				Update: `UPDATE "users" SET "email" = ? WHERE "id" = ?`,
+				Delete: `DELETE FROM "users" WHERE "id" = ?`,
+			},
+		},
+		{
+			desc:    "mssql outputs the auto increment columns",
+			dialect: DialectMsSQL,
+			expected: queries{
+				Select: "SELECT [id], [email], [full_name] FROM [users]",
+				Get:    "SELECT [id], [email], [full_name] FROM [users] WHERE [id] = @p1",
+				Insert: "INSERT INTO [users] ([email]) OUTPUT INSERTED.[id] VALUES (@p1)",
+				Update: "UPDATE [users] SET [email] = @p1 WHERE [id] = @p2",
+				Delete: "DELETE FROM [users] WHERE [id] = @p1",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			d := users
+			d.Dialect = test.dialect
+			actual := queries{
+				Select: d.selectQuery(),
+				Get:    d.getQuery(),
+				Insert: d.insertQuery(d.insertColumns()),
+				Update: d.updateQuery(d.updateColumns()),
+				Delete: d.deleteQuery(),
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDescriptor_InsertQueryDefaultValues(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dialect  Dialect
+		expected string
+	}{
+		{
+			desc:     "pg",
+			dialect:  DialectPostgresql,
+			expected: `INSERT INTO "t" DEFAULT VALUES RETURNING "id"`,
+		},
+		{
+			desc:     "mysql",
+			dialect:  DialectMySQL,
+			expected: "INSERT INTO `t` () VALUES ()",
+		},
+		{
+			desc:     "mssql",
+			dialect:  DialectMsSQL,
+			expected: "INSERT INTO [t] OUTPUT INSERTED.[id] DEFAULT VALUES",
+		},
+		{
+			desc:     "sqlite",
+			dialect:  DialectSQLite,
+			expected: `INSERT INTO "t" DEFAULT VALUES`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			d := Descriptor{
+				Table:         "t",
+				Columns:       []string{"id"},
+				AutoIncrement: []string{"id"},
+				Dialect:       test.dialect,
+			}
+			actual := d.insertQuery(d.insertColumns())
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDialect_Quote(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dialect  Dialect
+		name     string
+		expected string
+	}{
+		{
+			desc:     "pg escapes double quotes",
+			dialect:  DialectPostgresql,
+			name:     `my "table"`,
+			expected: `"my ""table"""`,
+		},
+		{
+			desc:     "mysql escapes backticks",
+			dialect:  DialectMySQL,
+			name:     "my `table`",
+			expected: "`my ``table```",
+		},
+		{
+			desc:     "mssql escapes closing brackets",
+			dialect:  DialectMsSQL,
+			name:     "my [table]",
+			expected: "[my [table]]]",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := test.dialect.Quote(test.name)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package runtime provides a generic Repository with typed CRUD operations
+// for the structs generated by tables-to-go. The table specifics are given by
+// a Descriptor, which is generated next to each struct with the flag
+// -descriptor.
+//
+// Besides, it provides a query builder on typed columns, which are generated
+// per table with the flag -typed-columns.
+package runtime
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL dialect of a database. The values are the same as the
+// database types of tables-to-go.
+type Dialect string
+
+// These are the supported dialects.
+const (
+	DialectPostgresql Dialect = "pg"
+	DialectMySQL      Dialect = "mysql"
+	DialectSQLite     Dialect = "sqlite3"
+	DialectMsSQL      Dialect = "mssql"
+)
+
+// Quote quotes the name of a table or column.
+func (d Dialect) Quote(name string) string {
+	switch d {
+	case DialectMySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case DialectMsSQL:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	default:
+		return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+}
+
+// Placeholder returns the n-th bind parameter, starting at 1.
+func (d Dialect) Placeholder(n int) string {
+	switch d {
+	case DialectPostgresql:
+		return "$" + strconv.Itoa(n)
+	case DialectMsSQL:
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// SupportsReturning returns true if the dialect can return the inserted auto
+// increment columns as part of the insert statement.
+func (d Dialect) SupportsReturning() bool {
+	return d == DialectPostgresql || d == DialectMsSQL
+}
+
+// SelectQuery returns the query selecting the columns of all rows of the
+// table.
+func (d Dialect) SelectQuery(table string, columns []string) string {
+	return "SELECT " + d.join(columns) + " FROM " + d.Quote(table)
+}
+
+// InsertQuery returns the query inserting the columns into the table. The
+// returning columns, eg. the auto increment ones, get returned if the dialect
+// supports it.
+func (d Dialect) InsertQuery(table string, columns, returning []string) string {
+	values := make([]string, 0, len(columns))
+	for i := range columns {
+		values = append(values, d.Placeholder(i+1))
+	}
+
+	query := "INSERT INTO " + d.Quote(table)
+
+	switch d {
+	case DialectMsSQL:
+		if len(columns) > 0 {
+			query += " (" + d.join(columns) + ")"
+		}
+		if len(returning) > 0 {
+			quoted := make([]string, 0, len(returning))
+			for _, column := range returning {
+				quoted = append(quoted, "INSERTED."+d.Quote(column))
+			}
+			query += " OUTPUT " + strings.Join(quoted, ", ")
+		}
+		if len(values) > 0 {
+			return query + " VALUES (" + strings.Join(values, ", ") + ")"
+		}
+		return query + " DEFAULT VALUES"
+	case DialectMySQL:
+		return query + " (" + d.join(columns) + ") VALUES (" + strings.Join(values, ", ") + ")"
+	}
+
+	if len(values) > 0 {
+		query += " (" + d.join(columns) + ") VALUES (" + strings.Join(values, ", ") + ")"
+	} else {
+		query += " DEFAULT VALUES"
+	}
+	if d == DialectPostgresql && len(returning) > 0 {
+		query += " RETURNING " + d.join(returning)
+	}
+
+	return query
+}
+
+// UpdateQuery returns the query updating the columns of the rows of the table
+// matching the keys.
+func (d Dialect) UpdateQuery(table string, columns, keys []string) string {
+	sets := make([]string, 0, len(columns))
+	for i, column := range columns {
+		sets = append(sets, d.Quote(column)+" = "+d.Placeholder(i+1))
+	}
+	return "UPDATE " + d.Quote(table) + " SET " + strings.Join(sets, ", ") +
+		" WHERE " + d.Where(keys, len(columns)+1)
+}
+
+// DeleteQuery returns the query deleting the rows of the table matching the
+// keys.
+func (d Dialect) DeleteQuery(table string, keys []string) string {
+	return "DELETE FROM " + d.Quote(table) + " WHERE " + d.Where(keys, 1)
+}
+
+// Where returns the condition matching the columns, numbering the
+// placeholders from start.
+func (d Dialect) Where(columns []string, start int) string {
+	conditions := make([]string, 0, len(columns))
+	for i, column := range columns {
+		conditions = append(conditions, d.Quote(column)+" = "+d.Placeholder(start+i))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// join returns the quoted columns separated by commas.
+func (d Dialect) join(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quoted = append(quoted, d.Quote(column))
+	}
+	return strings.Join(quoted, ", ")
+}
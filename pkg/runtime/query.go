@@ -0,0 +1,297 @@
+package runtime
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Expression is a part of a query, eg. a column or a condition.
+type Expression interface {
+	build(b *builder)
+}
+
+// Condition is an expression evaluating to true or false, eg. to filter rows
+// with Where.
+type Condition interface {
+	Expression
+	isCondition()
+}
+
+// Table is a table to build queries for.
+type Table struct {
+	name    string
+	dialect Dialect
+}
+
+// NewTable creates the Table name of a database with the given dialect.
+func NewTable(name string, dialect Dialect) Table {
+	return Table{name: name, dialect: dialect}
+}
+
+// TableName returns the name of the table.
+func (t Table) TableName() string {
+	return t.name
+}
+
+// Select starts a query selecting the columns of the rows of the table. All
+// columns get selected if none are given.
+func (t Table) Select(columns ...Expression) *SelectQuery {
+	return &SelectQuery{table: t, columns: columns}
+}
+
+// SelectQuery is a query selecting rows of a table.
+type SelectQuery struct {
+	table   Table
+	columns []Expression
+	where   []Condition
+	orderBy []Order
+	limit   int
+	offset  int
+}
+
+// Where filters the rows by the conditions, which must all be true.
+func (q *SelectQuery) Where(conditions ...Condition) *SelectQuery {
+	q.where = append(q.where, conditions...)
+	return q
+}
+
+// OrderBy orders the rows.
+func (q *SelectQuery) OrderBy(orders ...Order) *SelectQuery {
+	q.orderBy = append(q.orderBy, orders...)
+	return q
+}
+
+// Limit limits the number of rows.
+func (q *SelectQuery) Limit(limit int) *SelectQuery {
+	q.limit = limit
+	return q
+}
+
+// Offset skips the given number of rows.
+func (q *SelectQuery) Offset(offset int) *SelectQuery {
+	q.offset = offset
+	return q
+}
+
+// Build returns the query in the dialect of the table and its arguments.
+func (q *SelectQuery) Build() (string, []any) {
+	b := &builder{dialect: q.table.dialect}
+
+	b.write("SELECT ")
+	if len(q.columns) == 0 {
+		b.write("*")
+	}
+	b.list(q.columns, ", ")
+	b.write(" FROM " + b.dialect.Quote(q.table.name))
+
+	if len(q.where) > 0 {
+		b.write(" WHERE ")
+		And(q.where...).build(b)
+	}
+
+	if len(q.orderBy) > 0 {
+		b.write(" ORDER BY ")
+		for i, order := range q.orderBy {
+			if i > 0 {
+				b.write(", ")
+			}
+			order.build(b)
+		}
+	}
+
+	q.buildLimit(b)
+
+	return b.sb.String(), b.args
+}
+
+// buildLimit writes the limit and offset in the syntax of the dialect.
+func (q *SelectQuery) buildLimit(b *builder) {
+	if q.limit <= 0 && q.offset <= 0 {
+		return
+	}
+
+	if b.dialect == DialectMsSQL {
+		// MSSQL only supports paging of ordered rows.
+		if len(q.orderBy) == 0 {
+			b.write(" ORDER BY (SELECT NULL)")
+		}
+		b.write(" OFFSET " + strconv.Itoa(q.offset) + " ROWS")
+		if q.limit > 0 {
+			b.write(" FETCH NEXT " + strconv.Itoa(q.limit) + " ROWS ONLY")
+		}
+		return
+	}
+
+	if q.limit > 0 {
+		b.write(" LIMIT " + strconv.Itoa(q.limit))
+	} else if b.dialect == DialectMySQL {
+		// MySQL has no OFFSET without LIMIT.
+		b.write(" LIMIT 18446744073709551615")
+	} else if b.dialect == DialectSQLite {
+		b.write(" LIMIT -1")
+	}
+	if q.offset > 0 {
+		b.write(" OFFSET " + strconv.Itoa(q.offset))
+	}
+}
+
+// Order is the order of the rows by a column.
+type Order struct {
+	column Expression
+	desc   bool
+}
+
+// build writes the column and the direction.
+func (o Order) build(b *builder) {
+	o.column.build(b)
+	if o.desc {
+		b.write(" DESC")
+	} else {
+		b.write(" ASC")
+	}
+}
+
+// And is true if all conditions are true.
+func And(conditions ...Condition) Condition {
+	return junction{operator: " AND ", conditions: conditions}
+}
+
+// Or is true if any of the conditions is true.
+func Or(conditions ...Condition) Condition {
+	return junction{operator: " OR ", conditions: conditions}
+}
+
+// Not negates the condition.
+func Not(condition Condition) Condition {
+	return not{condition: condition}
+}
+
+// junction combines conditions by AND or OR.
+type junction struct {
+	operator   string
+	conditions []Condition
+}
+
+func (junction) isCondition() {}
+
+func (j junction) build(b *builder) {
+	switch len(j.conditions) {
+	case 0:
+		// The empty AND is true, the empty OR is false.
+		if j.operator == " AND " {
+			b.write("1 = 1")
+		} else {
+			b.write("1 = 0")
+		}
+	case 1:
+		j.conditions[0].build(b)
+	default:
+		b.write("(")
+		for i, condition := range j.conditions {
+			if i > 0 {
+				b.write(j.operator)
+			}
+			condition.build(b)
+		}
+		b.write(")")
+	}
+}
+
+// not negates a condition.
+type not struct {
+	condition Condition
+}
+
+func (not) isCondition() {}
+
+func (n not) build(b *builder) {
+	b.write("NOT (")
+	n.condition.build(b)
+	b.write(")")
+}
+
+// comparison compares a column with a value by the operator.
+type comparison struct {
+	column   Expression
+	operator string
+	value    any
+}
+
+func (comparison) isCondition() {}
+
+func (c comparison) build(b *builder) {
+	c.column.build(b)
+	b.write(" " + c.operator + " ")
+	b.bind(c.value)
+}
+
+// in checks if a column equals one of the values.
+type in struct {
+	column Expression
+	values []any
+}
+
+func (in) isCondition() {}
+
+func (i in) build(b *builder) {
+	// An empty IN list is invalid in most databases, but never matches.
+	if len(i.values) == 0 {
+		b.write("1 = 0")
+		return
+	}
+
+	i.column.build(b)
+	b.write(" IN (")
+	for n, value := range i.values {
+		if n > 0 {
+			b.write(", ")
+		}
+		b.bind(value)
+	}
+	b.write(")")
+}
+
+// null checks if a column is NULL or not.
+type null struct {
+	column Expression
+	not    bool
+}
+
+func (null) isCondition() {}
+
+func (n null) build(b *builder) {
+	n.column.build(b)
+	if n.not {
+		b.write(" IS NOT NULL")
+	} else {
+		b.write(" IS NULL")
+	}
+}
+
+// builder builds a query in a dialect and collects its arguments.
+type builder struct {
+	dialect Dialect
+	sb      strings.Builder
+	args    []any
+}
+
+// write writes the SQL as is.
+func (b *builder) write(sql string) {
+	b.sb.WriteString(sql)
+}
+
+// bind writes the next placeholder and adds value to the arguments.
+func (b *builder) bind(value any) {
+	b.args = append(b.args, value)
+	b.write(b.dialect.Placeholder(len(b.args)))
+}
+
+// list writes the expressions separated by sep.
+func (b *builder) list(expressions []Expression, sep string) {
+	for i, expression := range expressions {
+		if i > 0 {
+			b.write(sep)
+		}
+		expression.build(b)
+	}
+}
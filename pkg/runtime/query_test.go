@@ -0,0 +1,97 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectQuery_Build(t *testing.T) {
+	var (
+		id        = NewIntColumn("id")
+		email     = NewStringColumn("email")
+		score     = NewFloatColumn("score")
+		createdAt = NewTimeColumn("created_at")
+		active    = NewBoolColumn("active")
+	)
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc         string
+		query        func(users Table) *SelectQuery
+		dialect      Dialect
+		expected     string
+		expectedArgs []any
+	}{
+		{
+			desc: "all columns",
+			query: func(users Table) *SelectQuery {
+				return users.Select()
+			},
+			dialect:  DialectPostgresql,
+			expected: `SELECT * FROM "users"`,
+		},
+		{
+			desc: "pg numbers the placeholders",
+			query: func(users Table) *SelectQuery {
+				return users.Select(id, email).
+					Where(email.Eq("jane@example.com"), id.In(1, 2)).
+					OrderBy(id.Asc())
+			},
+			dialect:      DialectPostgresql,
+			expected:     `SELECT "id", "email" FROM "users" WHERE ("email" = $1 AND "id" IN ($2, $3)) ORDER BY "id" ASC`,
+			expectedArgs: []any{"jane@example.com", 1, 2},
+		},
+		{
+			desc: "mysql quotes with backticks",
+			query: func(users Table) *SelectQuery {
+				return users.Select(id).
+					Where(Or(score.Gte(0.5), active.Eq(true))).
+					OrderBy(createdAt.Desc(), id.Asc()).
+					Limit(10)
+			},
+			dialect:      DialectMySQL,
+			expected:     "SELECT `id` FROM `users` WHERE (`score` >= ? OR `active` = ?) ORDER BY `created_at` DESC, `id` ASC LIMIT 10",
+			expectedArgs: []any{0.5, true},
+		},
+		{
+			desc: "sqlite",
+			query: func(users Table) *SelectQuery {
+				return users.Select(email).
+					Where(email.Like("%@example.com"), createdAt.Lt(since), Not(email.IsNull())).
+					Offset(5)
+			},
+			dialect:      DialectSQLite,
+			expected:     `SELECT "email" FROM "users" WHERE ("email" LIKE ? AND "created_at" < ? AND NOT ("email" IS NULL)) LIMIT -1 OFFSET 5`,
+			expectedArgs: []any{"%@example.com", since},
+		},
+		{
+			desc: "mssql pages with offset and fetch",
+			query: func(users Table) *SelectQuery {
+				return users.Select(id).
+					Where(id.Ne(1)).
+					Limit(10).
+					Offset(20)
+			},
+			dialect:      DialectMsSQL,
+			expected:     "SELECT [id] FROM [users] WHERE [id] <> @p1 ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+			expectedArgs: []any{1},
+		},
+		{
+			desc: "empty IN matches no row",
+			query: func(users Table) *SelectQuery {
+				return users.Select(id).Where(id.In(), email.IsNotNull())
+			},
+			dialect:  DialectPostgresql,
+			expected: `SELECT "id" FROM "users" WHERE (1 = 0 AND "email" IS NOT NULL)`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, actualArgs := test.query(NewTable("users", test.dialect)).Build()
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expectedArgs, actualArgs)
+		})
+	}
+}
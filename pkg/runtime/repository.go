@@ -0,0 +1,196 @@
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+)
+
+// ErrNoPrimaryKey is returned by the operations on single rows of tables
+// without primary key.
+var ErrNoPrimaryKey = errors.New("table has no primary key")
+
+// Repository provides the CRUD operations for the rows of the table described
+// by the Descriptor. T is the struct of the table, whose fields are mapped to
+// the columns by their db-tags.
+type Repository[T any] struct {
+	db         sqlx.ExtContext
+	mapper     *reflectx.Mapper
+	descriptor Descriptor
+}
+
+// NewRepository creates a Repository for the table described by descriptor.
+func NewRepository[T any](db *sqlx.DB, descriptor Descriptor) *Repository[T] {
+	return &Repository[T]{
+		db:         db,
+		mapper:     db.Mapper,
+		descriptor: descriptor,
+	}
+}
+
+// WithTx returns a copy of the Repository executing the operations in the
+// given transaction.
+func (r *Repository[T]) WithTx(tx *sqlx.Tx) *Repository[T] {
+	return &Repository[T]{
+		db:         tx,
+		mapper:     tx.Mapper,
+		descriptor: r.descriptor,
+	}
+}
+
+// Descriptor returns the descriptor of the table.
+func (r *Repository[T]) Descriptor() Descriptor {
+	return r.descriptor
+}
+
+// Get returns the row with the given values of the primary key columns in
+// their order. If there is no such row, sql.ErrNoRows is returned.
+func (r *Repository[T]) Get(ctx context.Context, key ...any) (*T, error) {
+	if err := r.checkKey(key); err != nil {
+		return nil, err
+	}
+
+	var row T
+	err := sqlx.GetContext(ctx, r.db, &row, r.descriptor.getQuery(), key...)
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// List returns all rows.
+func (r *Repository[T]) List(ctx context.Context) ([]T, error) {
+	var rows []T
+	err := sqlx.SelectContext(ctx, r.db, &rows, r.descriptor.selectQuery())
+	return rows, err
+}
+
+// Insert inserts the row without its auto increment and generated columns.
+// The auto increment columns of the row get set afterwards.
+func (r *Repository[T]) Insert(ctx context.Context, row *T) error {
+	columns := r.descriptor.insertColumns()
+	args, err := r.values(row, columns)
+	if err != nil {
+		return err
+	}
+
+	query := r.descriptor.insertQuery(columns)
+	autoIncrement := r.descriptor.AutoIncrement
+
+	if len(autoIncrement) > 0 && r.descriptor.Dialect.SupportsReturning() {
+		return sqlx.GetContext(ctx, r.db, row, query, args...)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	// Only a single auto increment column can be set by the last insert ID.
+	if len(autoIncrement) != 1 {
+		return nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	return r.setID(row, autoIncrement[0], id)
+}
+
+// Update updates the row by its primary key and returns the number of
+// affected rows.
+func (r *Repository[T]) Update(ctx context.Context, row *T) (int64, error) {
+	if len(r.descriptor.PrimaryKey) == 0 {
+		return 0, ErrNoPrimaryKey
+	}
+
+	columns := r.descriptor.updateColumns()
+	if len(columns) == 0 {
+		return 0, nil
+	}
+
+	args, err := r.values(row, append(columns, r.descriptor.PrimaryKey...))
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.ExecContext(ctx, r.descriptor.updateQuery(columns), args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Delete deletes the row with the given values of the primary key columns in
+// their order and returns the number of affected rows.
+func (r *Repository[T]) Delete(ctx context.Context, key ...any) (int64, error) {
+	if err := r.checkKey(key); err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.ExecContext(ctx, r.descriptor.deleteQuery(), key...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// checkKey checks that the key has a value for every primary key column.
+func (r *Repository[T]) checkKey(key []any) error {
+	if len(r.descriptor.PrimaryKey) == 0 {
+		return ErrNoPrimaryKey
+	}
+	if len(key) != len(r.descriptor.PrimaryKey) {
+		return fmt.Errorf("expected %d values of the primary key %v, got %d",
+			len(r.descriptor.PrimaryKey), r.descriptor.PrimaryKey, len(key))
+	}
+	return nil
+}
+
+// values returns the values of the fields of the row mapped to the given
+// columns in their order.
+func (r *Repository[T]) values(row *T, columns []string) ([]any, error) {
+	v := reflect.ValueOf(row).Elem()
+
+	args := make([]any, 0, len(columns))
+	for i, traversal := range r.mapper.TraversalsByName(v.Type(), columns) {
+		if len(traversal) == 0 {
+			return nil, fmt.Errorf("missing field for column %q in %s", columns[i], v.Type())
+		}
+		args = append(args, reflectx.FieldByIndexesReadOnly(v, traversal).Interface())
+	}
+
+	return args, nil
+}
+
+// setID sets the field of the row mapped to the column to the given ID.
+func (r *Repository[T]) setID(row *T, column string, id int64) error {
+	v := reflect.ValueOf(row).Elem()
+
+	traversal := r.mapper.TraversalsByName(v.Type(), []string{column})[0]
+	if len(traversal) == 0 {
+		return fmt.Errorf("missing field for column %q in %s", column, v.Type())
+	}
+
+	field := reflectx.FieldByIndexes(v, traversal)
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(id))
+	default:
+		scanner, ok := field.Addr().Interface().(sql.Scanner)
+		if !ok {
+			return fmt.Errorf("can not set column %q of type %s to the inserted ID", column, field.Type())
+		}
+		return scanner.Scan(id)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package runtime
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID    int            `db:"id"`
+	Email string         `db:"email"`
+	Name  sql.NullString `db:"name"`
+}
+
+func newUserRepository(descriptor Descriptor) *Repository[user] {
+	return NewRepository[user](sqlx.NewDb(nil, "postgres"), descriptor)
+}
+
+func TestRepository_values(t *testing.T) {
+	tests := []struct {
+		desc     string
+		columns  []string
+		expected []any
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "values of the columns in their order",
+			columns:  []string{"name", "id"},
+			expected: []any{sql.NullString{String: "Jane", Valid: true}, 1},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "column without field produces error",
+			columns:  []string{"id", "unknown"},
+			expected: nil,
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			r := newUserRepository(Descriptor{})
+			row := &user{ID: 1, Email: "jane@example.com", Name: sql.NullString{String: "Jane", Valid: true}}
+			actual, err := r.values(row, test.columns)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRepository_setID(t *testing.T) {
+	tests := []struct {
+		desc     string
+		column   string
+		expected user
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "integer field gets set",
+			column:   "id",
+			expected: user{ID: 42},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "scanner field gets scanned",
+			column:   "name",
+			expected: user{Name: sql.NullString{String: "42", Valid: true}},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "string field produces error",
+			column:   "email",
+			expected: user{},
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			r := newUserRepository(Descriptor{})
+			var actual user
+			err := r.setID(&actual, test.column, 42)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRepository_NoPrimaryKey(t *testing.T) {
+	r := newUserRepository(Descriptor{Table: "users", Columns: []string{"id", "email", "name"}})
+
+	_, err := r.Get(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNoPrimaryKey)
+
+	_, err = r.Update(context.Background(), &user{})
+	assert.ErrorIs(t, err, ErrNoPrimaryKey)
+
+	_, err = r.Delete(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNoPrimaryKey)
+}
+
+func TestRepository_WrongKey(t *testing.T) {
+	r := newUserRepository(Descriptor{Table: "users", Columns: []string{"id"}, PrimaryKey: []string{"id"}})
+
+	_, err := r.Get(context.Background(), 1, 2)
+	assert.EqualError(t, err, "expected 1 values of the primary key [id], got 2")
+}
+
+// execConn is a connection recording the executed statements. Its results
+// have no last insert ID.
+type execConn struct {
+	queries []string
+	args    [][]any
+}
+
+func (c *execConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *execConn) Driver() driver.Driver                        { return nil }
+func (c *execConn) Prepare(string) (driver.Stmt, error)          { return nil, errors.New("not supported") }
+func (c *execConn) Close() error                                 { return nil }
+func (c *execConn) Begin() (driver.Tx, error)                    { return nil, errors.New("not supported") }
+
+func (c *execConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, values)
+	return execResult{}, nil
+}
+
+type execResult struct{}
+
+func (execResult) LastInsertId() (int64, error) { return 0, errors.New("no last insert ID") }
+func (execResult) RowsAffected() (int64, error) { return 1, nil }
+
+func TestRepository_InsertKeyWithoutAutoIncrement(t *testing.T) {
+	type session struct {
+		Token string `db:"token"`
+		Email string `db:"email"`
+	}
+
+	conn := &execConn{}
+	r := NewRepository[session](sqlx.NewDb(sql.OpenDB(conn), "sqlite3"), Descriptor{
+		Table:      "sessions",
+		Columns:    []string{"token", "email"},
+		PrimaryKey: []string{"token"},
+		Dialect:    DialectSQLite,
+	})
+
+	row := &session{Token: "abc", Email: "jane@example.com"}
+	err := r.Insert(context.Background(), row)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`INSERT INTO "sessions" ("token", "email") VALUES (?, ?)`}, conn.queries)
+	assert.Equal(t, [][]any{{"abc", "jane@example.com"}}, conn.args)
+	assert.Equal(t, &session{Token: "abc", Email: "jane@example.com"}, row)
+}
@@ -1,9 +1,15 @@
 package settings
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // DBType represents a type of a database.
@@ -15,6 +21,7 @@ const (
 	DBTypeMySQL      DBType = "mysql"
 	DBTypeSQLite     DBType = "sqlite3"
 	DBTypeMsSQL      DBType = "mssql"
+	DBTypeGeneric    DBType = "generic"
 )
 
 // Set sets the datatype for the custom type for the flag package.
@@ -23,7 +30,7 @@ func (db *DBType) Set(s string) error {
 	if *db == "" {
 		*db = DBTypePostgresql
 	}
-	if !SupportedDbTypes[*db] {
+	if !isSupportedDbType(*db) {
 		return fmt.Errorf("database type %q not supported, must be one of: %v",
 			*db, SprintfSupportedDbTypes())
 	}
@@ -118,28 +125,255 @@ func (of FileNameFormat) String() string {
 	return string(of)
 }
 
-var (
-	// SupportedDbTypes represents the supported databases
-	SupportedDbTypes = map[DBType]bool{
-		DBTypePostgresql: true,
-		DBTypeMySQL:      true,
-		DBTypeSQLite:     true,
-		DBTypeMsSQL:      true,
+// Inflection represents an English inflection applied to table names.
+type Inflection string
+
+// These are the Inflection command line parameter.
+const (
+	InflectionNone     Inflection = "none"
+	InflectionSingular Inflection = "singular"
+	InflectionPlural   Inflection = "plural"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (i *Inflection) Set(s string) error {
+	*i = Inflection(s)
+	if *i == "" {
+		*i = InflectionNone
+	}
+	if !supportedInflections[*i] {
+		return fmt.Errorf("inflection %q not supported", *i)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (i Inflection) String() string {
+	return string(i)
+}
+
+// LogFormat represents the format of the log output.
+type LogFormat string
+
+// These are the LogFormat command line parameter.
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (f *LogFormat) Set(s string) error {
+	*f = LogFormat(s)
+	if *f == "" {
+		*f = LogFormatText
+	}
+	if !supportedLogFormats[*f] {
+		return fmt.Errorf("log format %q not supported", *f)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (f LogFormat) String() string {
+	return string(f)
+}
+
+// Dialect represents the SQL dialect of the generic database type, named by
+// the database type speaking it.
+type Dialect string
+
+// Set sets the datatype for the custom type for the flag package.
+func (d *Dialect) Set(s string) error {
+	*d = Dialect(s)
+	if *d != "" && !supportedDialects[*d] {
+		return fmt.Errorf("dialect %q not supported, must be one of: %v", *d, SprintfSupportedDialects())
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (d Dialect) String() string {
+	return string(d)
+}
+
+// LevelTrace is the log level of the output of VVerbose, below slog.LevelDebug
+// of Verbose.
+const LevelTrace = slog.LevelDebug - 4
+
+// JSONNaming represents the naming strategy of the json-tags.
+type JSONNaming string
+
+// These are the JSONNaming command line parameter.
+const (
+	JSONNamingOriginal  JSONNaming = "o"
+	JSONNamingSnakeCase JSONNaming = "s"
+	JSONNamingCamelCase JSONNaming = "c"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (n *JSONNaming) Set(s string) error {
+	*n = JSONNaming(s)
+	if *n == "" {
+		*n = JSONNamingOriginal
+	}
+	if !supportedJSONNamings[*n] {
+		return fmt.Errorf("json naming %q not supported", *n)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (n JSONNaming) String() string {
+	return string(n)
+}
+
+// OmitEmpty represents the policy when to add the omitempty option to tags.
+type OmitEmpty string
+
+// These are the OmitEmpty command line parameter.
+const (
+	OmitEmptyNever    OmitEmpty = "never"
+	OmitEmptyNullable OmitEmpty = "nullable"
+	OmitEmptyAlways   OmitEmpty = "always"
+)
+
+// Set sets the datatype for the custom type for the flag package.
+func (o *OmitEmpty) Set(s string) error {
+	*o = OmitEmpty(s)
+	if *o == "" {
+		*o = OmitEmptyNever
+	}
+	if !supportedOmitEmpties[*o] {
+		return fmt.Errorf("omitempty policy %q not supported", *o)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (o OmitEmpty) String() string {
+	return string(o)
+}
+
+// StringList represents a comma separated list of strings. The flag can be
+// specified multiple times, each occurrence appends to the list.
+type StringList []string
+
+// Set appends the comma separated values for the custom type for the flag
+// package. Empty values are ignored.
+func (l *StringList) Set(s string) error {
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		*l = append(*l, v)
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (l StringList) String() string {
+	return strings.Join(l, ",")
+}
+
+// StringMap represents a comma separated list of key=value pairs. The flag can
+// be specified multiple times, each occurrence adds to the map.
+type StringMap map[string]string
+
+// Set adds the comma separated key=value pairs for the custom type for the
+// flag package. Empty pairs are ignored.
+func (m *StringMap) Set(s string) error {
+	if *m == nil {
+		*m = StringMap{}
+	}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return fmt.Errorf("invalid pair %q, must be of form key=value", pair)
+		}
+		(*m)[key] = value
+	}
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (m StringMap) String() string {
+	pairs := make([]string, 0, len(m))
+	for key, value := range m {
+		pairs = append(pairs, key+"="+value)
 	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// RawStringList represents a list of strings like StringList, but every
+// occurrence of the flag is a single value which is taken as is, so values
+// can contain commas.
+type RawStringList []string
+
+// Set appends the value for the custom type for the flag package.
+func (l *RawStringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (l RawStringList) String() string {
+	return StringList(l).String()
+}
 
+// RawStringMap represents key=value pairs like StringMap, but every occurrence
+// of the flag is a single pair whose value is taken as is, so values can
+// contain commas.
+type RawStringMap map[string]string
+
+// Set adds the key=value pair for the custom type for the flag package.
+func (m *RawStringMap) Set(s string) error {
+	if *m == nil {
+		*m = RawStringMap{}
+	}
+	key, value, ok := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid pair %q, must be of form key=value", s)
+	}
+	(*m)[key] = value
+	return nil
+}
+
+// String is the implementation of the Stringer interface needed for
+// flag.Value interface.
+func (m RawStringMap) String() string {
+	return StringMap(m).String()
+}
+
+var (
 	// supportedOutputFormats represents the supported output formats
 	supportedOutputFormats = map[OutputFormat]bool{
 		OutputFormatCamelCase: true,
 		OutputFormatOriginal:  true,
 	}
 
-	// dbDefaultPorts maps the database type to the default ports
-	dbDefaultPorts = map[DBType]string{
-		DBTypePostgresql: "5432",
-		DBTypeMySQL:      "3306",
-		DBTypeMsSQL:      "1433",
-		DBTypeSQLite:     "",
-	}
+	// dbDefaultPorts maps the supported database types to their default
+	// ports. They get added by RegisterDbType, which database.Register calls
+	// for the built-in and the third party databases.
+	dbDefaultPorts = map[DBType]string{}
+
+	// dbTypesMu guards dbDefaultPorts against concurrent registrations.
+	dbTypesMu sync.RWMutex
 
 	// supportedNullTypes represents the supported types of NULL types
 	supportedNullTypes = map[NullType]bool{
@@ -154,6 +388,42 @@ var (
 		FileNameFormatCamelCase: true,
 		FileNameFormatSnakeCase: true,
 	}
+
+	// supportedJSONNamings represents the supported json naming strategies
+	supportedJSONNamings = map[JSONNaming]bool{
+		JSONNamingOriginal:  true,
+		JSONNamingSnakeCase: true,
+		JSONNamingCamelCase: true,
+	}
+
+	// supportedOmitEmpties represents the supported omitempty policies
+	supportedOmitEmpties = map[OmitEmpty]bool{
+		OmitEmptyNever:    true,
+		OmitEmptyNullable: true,
+		OmitEmptyAlways:   true,
+	}
+
+	// supportedLogFormats represents the supported log formats
+	supportedLogFormats = map[LogFormat]bool{
+		LogFormatText: true,
+		LogFormatJSON: true,
+	}
+
+	// supportedDialects represents the supported dialects of the generic
+	// database type
+	supportedDialects = map[Dialect]bool{
+		Dialect(DBTypePostgresql): true,
+		Dialect(DBTypeMySQL):      true,
+		Dialect(DBTypeSQLite):     true,
+		Dialect(DBTypeMsSQL):      true,
+	}
+
+	// supportedInflections represents the supported inflections
+	supportedInflections = map[Inflection]bool{
+		InflectionNone:     true,
+		InflectionSingular: true,
+		InflectionPlural:   true,
+	}
 )
 
 // Settings stores the supported settings / command line arguments.
@@ -162,6 +432,10 @@ type Settings struct {
 	VVerbose bool
 	Force    bool // continue through errors
 
+	LogFormat LogFormat
+	Logger    *slog.Logger // receives the diagnostics, discarded if nil
+	Report    string       // path of the JSON report of the run
+
 	DbType DBType
 
 	User    string
@@ -173,6 +447,11 @@ type Settings struct {
 	SSLMode string
 	Socket  string
 
+	Driver      string  // database/sql driver of the generic database type
+	DataSource  string  // data source name passed as is to the driver of the generic database type
+	TablesQuery string  // query of the table names of the generic database type
+	Dialect     Dialect // SQL dialect of the generic database type, eg. pg for CockroachDB
+
 	OutputFilePath string
 	OutputFormat   OutputFormat
 
@@ -181,8 +460,16 @@ type Settings struct {
 	Prefix         string
 	Suffix         string
 	Null           NullType
+	Template       string // path of the text/template file to render the structs with
+
+	StructNameInflection Inflection
+	FileNameInflection   Inflection
+	Irregulars           StringMap // singular=plural pairs overriding the inflection rules
+
+	Renames StringMap // table=Name and table.column=Name pairs of explicit Go names
 
 	NoInitialism bool
+	Initialisms  StringList // additional initialisms besides the common ones
 
 	TagsNoDb bool
 
@@ -190,8 +477,35 @@ type Settings struct {
 	TagsMastermindStructableOnly   bool
 	IsMastermindStructableRecorder bool
 
-	// TODO not implemented yet
 	TagsGorm bool
+
+	TagsJSON      bool
+	JSONNaming    JSONNaming
+	JSONOmitEmpty OmitEmpty
+
+	TagsValidate  bool
+	ValidateRules RawStringMap // table=rules and table.column=rules overriding the derived rules
+
+	TagsBun  bool
+	TagsXorm bool
+
+	GenerateColumns    bool // TableName() method and column names per struct
+	GenerateRepository bool // sqlx based CRUD functions per struct
+	GenerateScan       bool // reflection-free ScanRow() and Values() methods per struct
+
+	GenerateUpsert bool
+	UpsertExclude  StringList // column and table.column names neither inserted nor updated by the upsert
+
+	GenerateDescriptor   bool // table descriptor per struct for the generic runtime.Repository
+	GenerateTypedColumns bool // typed columns per struct for the query builder of the runtime package
+
+	SQLFiles StringList // SQL files or directories with annotated queries to generate functions for instead of the tables
+
+	Query     string // SELECT statement to generate a struct for instead of the tables
+	QueryName string // name of the struct of the Query
+
+	TagTemplates RawStringList // text/template snippets of user-defined tags
+	TagOrder     StringList    // keys of the tags in the order to generate them
 }
 
 // New constructs Settings with default values.
@@ -207,6 +521,10 @@ func New() *Settings {
 		VVerbose: false,
 		Force:    false,
 
+		LogFormat: LogFormatText,
+		Logger:    nil,
+		Report:    "",
+
 		DbType:         DBTypePostgresql,
 		User:           "",
 		Pswd:           "",
@@ -216,6 +534,9 @@ func New() *Settings {
 		Port:           "", // left blank, automatically determined if not set
 		SSLMode:        "", // left blank, will set the default for Postgres to 'disable'
 		Socket:         "",
+		Driver:         "",
+		DataSource:     "",
+		TablesQuery:    "",
 		OutputFilePath: dir,
 		OutputFormat:   OutputFormatCamelCase,
 		FileNameFormat: FileNameFormatCamelCase,
@@ -224,7 +545,14 @@ func New() *Settings {
 		Suffix:         "",
 		Null:           NullTypeSQL,
 
+		StructNameInflection: InflectionNone,
+		FileNameInflection:   InflectionNone,
+		Irregulars:           StringMap{},
+
+		Renames: StringMap{},
+
 		NoInitialism: false,
+		Initialisms:  StringList{},
 
 		TagsNoDb: false,
 
@@ -233,6 +561,34 @@ func New() *Settings {
 		IsMastermindStructableRecorder: false,
 
 		TagsGorm: false,
+
+		TagsJSON:      false,
+		JSONNaming:    JSONNamingOriginal,
+		JSONOmitEmpty: OmitEmptyNever,
+
+		TagsValidate:  false,
+		ValidateRules: RawStringMap{},
+
+		TagsBun:  false,
+		TagsXorm: false,
+
+		GenerateColumns:    false,
+		GenerateRepository: false,
+		GenerateScan:       false,
+
+		GenerateUpsert: false,
+		UpsertExclude:  StringList{},
+
+		GenerateDescriptor:   false,
+		GenerateTypedColumns: false,
+
+		SQLFiles: StringList{},
+
+		Query:     "",
+		QueryName: "",
+
+		TagTemplates: RawStringList{},
+		TagOrder:     StringList{},
 	}
 }
 
@@ -247,18 +603,63 @@ func (settings *Settings) Verify() (err error) {
 		return err
 	}
 
+	if !isSupportedDbType(settings.DbType) {
+		return fmt.Errorf("database type %q not supported, must be one of: %v",
+			settings.DbType, SprintfSupportedDbTypes())
+	}
+
 	if settings.Port == "" {
-		settings.Port = dbDefaultPorts[settings.DbType]
+		settings.Port = defaultPort(settings.DbType)
 	}
 
 	if settings.SSLMode == "" {
 		settings.SSLMode = "disable"
 	}
 
+	if settings.DbType == DBTypeGeneric && (settings.Driver == "" || settings.DataSource == "") {
+		return fmt.Errorf("generic database type needs a driver and a data source name")
+	}
+
+	if settings.Dialect != "" {
+		if settings.DbType != DBTypeGeneric {
+			return fmt.Errorf("dialect is only supported by the generic database type")
+		}
+		if !supportedDialects[settings.Dialect] {
+			return fmt.Errorf("dialect %q not supported, must be one of: %v",
+				settings.Dialect, SprintfSupportedDialects())
+		}
+	}
+
 	if settings.PackageName == "" {
 		return fmt.Errorf("name of package can not be empty")
 	}
 
+	if settings.GenerateRepository && (settings.TagsNoDb || settings.TagsMastermindStructableOnly) {
+		return fmt.Errorf("repository needs the db-tags")
+	}
+
+	if settings.GenerateDescriptor && (settings.TagsNoDb || settings.TagsMastermindStructableOnly) {
+		return fmt.Errorf("descriptor needs the db-tags")
+	}
+
+	if (settings.Query == "") != (settings.QueryName == "") {
+		return fmt.Errorf("query needs a name and vice versa")
+	}
+
+	if settings.Query != "" {
+		if len(settings.SQLFiles) > 0 {
+			return fmt.Errorf("query can not be combined with SQL files")
+		}
+		if settings.GenerateColumns || settings.GenerateRepository || settings.GenerateUpsert ||
+			settings.GenerateDescriptor || settings.GenerateTypedColumns {
+			return fmt.Errorf("query can not be combined with the generation of table specific code")
+		}
+	}
+
+	if !supportedLogFormats[settings.LogFormat] {
+		return fmt.Errorf("log format %q not supported", settings.LogFormat)
+	}
+
 	if settings.VVerbose {
 		settings.Verbose = true
 	}
@@ -266,6 +667,48 @@ func (settings *Settings) Verify() (err error) {
 	return err
 }
 
+// LogLevel returns the minimum level of the log output: LevelTrace for
+// VVerbose, slog.LevelDebug for Verbose and slog.LevelInfo otherwise.
+func (settings *Settings) LogLevel() slog.Level {
+	switch {
+	case settings.VVerbose:
+		return LevelTrace
+	case settings.Verbose:
+		return slog.LevelDebug
+	}
+	return slog.LevelInfo
+}
+
+// NewLogger creates a logger writing to w in the LogFormat with the LogLevel
+// of the settings.
+func (settings *Settings) NewLogger(w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: settings.LogLevel()}
+	if settings.LogFormat == LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// Log returns the Logger of the settings, or a logger discarding everything
+// if there is none. It is safe to call on nil settings.
+func (settings *Settings) Log() *slog.Logger {
+	if settings == nil || settings.Logger == nil {
+		return discardLogger
+	}
+	return settings.Logger
+}
+
+// discardLogger is the logger of settings without a Logger.
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler is a slog.Handler discarding all records.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
 func (settings *Settings) verifyOutputPath() (err error) {
 
 	info, err := os.Stat(settings.OutputFilePath)
@@ -287,13 +730,55 @@ func (settings *Settings) prepareOutputPath() (outputFilePath string, err error)
 	return outputFilePath, err
 }
 
+// RegisterDbType adds the database type with its default port to the
+// supported database types. It gets called by database.Register.
+func RegisterDbType(dbType DBType, defaultPort string) {
+	dbTypesMu.Lock()
+	defer dbTypesMu.Unlock()
+	dbDefaultPorts[dbType] = defaultPort
+}
+
+// SupportedDbTypes returns the registered database types, sorted by name.
+func SupportedDbTypes() []DBType {
+	dbTypesMu.RLock()
+	dbTypes := make([]DBType, 0, len(dbDefaultPorts))
+	for dbType := range dbDefaultPorts {
+		dbTypes = append(dbTypes, dbType)
+	}
+	dbTypesMu.RUnlock()
+	sort.Slice(dbTypes, func(i, j int) bool { return dbTypes[i] < dbTypes[j] })
+	return dbTypes
+}
+
+// isSupportedDbType returns true if the database type is registered.
+func isSupportedDbType(dbType DBType) bool {
+	dbTypesMu.RLock()
+	defer dbTypesMu.RUnlock()
+	_, ok := dbDefaultPorts[dbType]
+	return ok
+}
+
+// defaultPort returns the default port of the database type.
+func defaultPort(dbType DBType) string {
+	dbTypesMu.RLock()
+	defer dbTypesMu.RUnlock()
+	return dbDefaultPorts[dbType]
+}
+
 // SprintfSupportedDbTypes returns a slice of strings as names of the supported
 // database types
 func SprintfSupportedDbTypes() string {
-	names := make([]string, 0, len(SupportedDbTypes))
-	for name := range SupportedDbTypes {
+	return fmt.Sprintf("%v", SupportedDbTypes())
+}
+
+// SprintfSupportedDialects returns a slice of strings as names of the
+// supported dialects of the generic database type
+func SprintfSupportedDialects() string {
+	names := make([]string, 0, len(supportedDialects))
+	for name := range supportedDialects {
 		names = append(names, string(name))
 	}
+	sort.Strings(names)
 	return fmt.Sprintf("%v", names)
 }
 
@@ -307,6 +792,40 @@ func SprintfSupportedNullTypes() string {
 	return fmt.Sprintf("%v", names)
 }
 
+// TableRename returns the explicit Go name of the given table, if any.
+func (settings *Settings) TableRename(table string) (string, bool) {
+	name, ok := settings.Renames[table]
+	return name, ok
+}
+
+// ColumnRename returns the explicit Go name of the given column of the given
+// table, if any.
+func (settings *Settings) ColumnRename(table, column string) (string, bool) {
+	name, ok := settings.Renames[table+"."+column]
+	return name, ok
+}
+
+// SQLDialect returns the database type whose SQL syntax the database speaks:
+// the database type itself, or the dialect of the generic database type,
+// which is empty if unknown.
+func (settings *Settings) SQLDialect() DBType {
+	if settings.DbType == DBTypeGeneric {
+		return DBType(settings.Dialect)
+	}
+	return settings.DbType
+}
+
+// IsUpsertExcluded returns true if the given column of the table is excluded
+// from the upsert, either for all tables or for the given one.
+func (settings *Settings) IsUpsertExcluded(table, column string) bool {
+	for _, excluded := range settings.UpsertExclude {
+		if excluded == column || excluded == table+"."+column {
+			return true
+		}
+	}
+	return false
+}
+
 // IsNullTypeSQL returns true if the type given by the command line args is of
 // null type SQL
 func (settings *Settings) IsNullTypeSQL() bool {
@@ -1,6 +1,9 @@
 package settings
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"os"
 	"strings"
 	"testing"
@@ -8,6 +11,18 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestMain(m *testing.M) {
+	// the database types get registered by the database package, which can't
+	// be imported here
+	RegisterDbType(DBTypePostgresql, "5432")
+	RegisterDbType(DBTypeMySQL, "3306")
+	RegisterDbType(DBTypeSQLite, "")
+	RegisterDbType(DBTypeMsSQL, "1433")
+	RegisterDbType(DBTypeGeneric, "")
+
+	os.Exit(m.Run())
+}
+
 func TestSettings_Verify(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -48,6 +63,118 @@ func TestSettings_Verify(t *testing.T) {
 			},
 			isError: assert.Error,
 		},
+		{
+			desc: "repository without db-tags produces error",
+			settings: func() *Settings {
+				s := New()
+				s.GenerateRepository = true
+				s.TagsNoDb = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "descriptor without db-tags produces error",
+			settings: func() *Settings {
+				s := New()
+				s.GenerateDescriptor = true
+				s.TagsMastermindStructableOnly = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generic database type without driver produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeGeneric
+				s.DataSource = "postgres://root@localhost:26257/defaultdb"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "generic database type with dialect produces no error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeGeneric
+				s.Driver = "postgres"
+				s.DataSource = "postgres://root@localhost:26257/defaultdb"
+				s.Dialect = Dialect(DBTypePostgresql)
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "generic database type with unsupported dialect produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = DBTypeGeneric
+				s.Driver = "postgres"
+				s.DataSource = "postgres://root@localhost:26257/defaultdb"
+				s.Dialect = Dialect(DBTypeGeneric)
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "dialect of other database type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Dialect = Dialect(DBTypeMySQL)
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "query without name produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Query = "SELECT 1 AS one"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "query with table specific code produces error",
+			settings: func() *Settings {
+				s := New()
+				s.Query = "SELECT 1 AS one"
+				s.QueryName = "One"
+				s.GenerateRepository = true
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "query with name produces no error",
+			settings: func() *Settings {
+				s := New()
+				s.Query = "SELECT 1 AS one"
+				s.QueryName = "One"
+				s.GenerateScan = true
+				return s
+			},
+			isError: assert.NoError,
+		},
+		{
+			desc: "unregistered database type produces error",
+			settings: func() *Settings {
+				s := New()
+				s.DbType = "invalid"
+				return s
+			},
+			isError: assert.Error,
+		},
+		{
+			desc: "unsupported log format produces error",
+			settings: func() *Settings {
+				s := New()
+				s.LogFormat = "xml"
+				return s
+			},
+			isError: assert.Error,
+		},
 		{
 			desc: "set v-verbose mode activates verbose mode without error",
 			settings: func() *Settings {
@@ -250,6 +377,52 @@ func TestSettings_IsFileNameFormatSnakeCase(t *testing.T) {
 	}
 }
 
+func TestSettings_IsUpsertExcluded(t *testing.T) {
+	tests := []struct {
+		desc     string
+		exclude  StringList
+		table    string
+		column   string
+		expected bool
+	}{
+		{
+			desc:     "in default settings no column is excluded",
+			table:    "users",
+			column:   "created_at",
+			expected: false,
+		},
+		{
+			desc:     "column is excluded for all tables",
+			exclude:  StringList{"created_at"},
+			table:    "users",
+			column:   "created_at",
+			expected: true,
+		},
+		{
+			desc:     "column of table is excluded",
+			exclude:  StringList{"users.created_at"},
+			table:    "users",
+			column:   "created_at",
+			expected: true,
+		},
+		{
+			desc:     "column of other table is not excluded",
+			exclude:  StringList{"orders.created_at"},
+			table:    "users",
+			column:   "created_at",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			settings := New()
+			settings.UpsertExclude = test.exclude
+			actual := settings.IsUpsertExcluded(test.table, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestDbType_Set(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -418,6 +591,473 @@ func TestFileNameFormat_Set(t *testing.T) {
 	}
 }
 
+func TestJSONNaming_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected JSONNaming
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "supported json naming produces no error and gets set",
+			input:    "s",
+			expected: JSONNamingSnakeCase,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty json naming produces no error and gets default",
+			input:    "",
+			expected: JSONNamingOriginal,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unsupported json naming produces error and invalid json naming",
+			input:    "kebab",
+			expected: JSONNaming("kebab"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := JSONNamingCamelCase
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestOmitEmpty_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected OmitEmpty
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "supported omitempty policy produces no error and gets set",
+			input:    "nullable",
+			expected: OmitEmptyNullable,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty omitempty policy produces no error and gets default",
+			input:    "",
+			expected: OmitEmptyNever,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unsupported omitempty policy produces error and invalid omitempty policy",
+			input:    "sometimes",
+			expected: OmitEmpty("sometimes"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := OmitEmptyAlways
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestStringList_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inputs   []string
+		expected StringList
+	}{
+		{
+			desc:     "single value gets appended",
+			inputs:   []string{"SKU"},
+			expected: StringList{"SKU"},
+		},
+		{
+			desc:     "comma separated values get appended",
+			inputs:   []string{"SKU,IBAN, GTIN"},
+			expected: StringList{"SKU", "IBAN", "GTIN"},
+		},
+		{
+			desc:     "multiple calls append to the list",
+			inputs:   []string{"SKU", "IBAN"},
+			expected: StringList{"SKU", "IBAN"},
+		},
+		{
+			desc:     "empty values are ignored",
+			inputs:   []string{"", "SKU,,"},
+			expected: StringList{"SKU"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var actual StringList
+			for _, input := range test.inputs {
+				err := actual.Set(input)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestInflection_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected Inflection
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "supported inflection produces no error and gets set",
+			input:    "singular",
+			expected: InflectionSingular,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty inflection produces no error and gets default",
+			input:    "",
+			expected: InflectionNone,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unsupported inflection produces error and invalid inflection",
+			input:    "dual",
+			expected: Inflection("dual"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := InflectionPlural
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestLogFormat_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected LogFormat
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "supported log format produces no error and gets set",
+			input:    "json",
+			expected: LogFormatJSON,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty log format produces no error and gets default",
+			input:    "",
+			expected: LogFormatText,
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unsupported log format produces error and invalid log format",
+			input:    "xml",
+			expected: LogFormat("xml"),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := LogFormatJSON
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDialect_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		expected Dialect
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "supported dialect produces no error and gets set",
+			input:    "mysql",
+			expected: Dialect(DBTypeMySQL),
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "empty dialect produces no error",
+			input:    "",
+			expected: Dialect(""),
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "unsupported dialect produces error and invalid dialect",
+			input:    "generic",
+			expected: Dialect(DBTypeGeneric),
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := Dialect(DBTypePostgresql)
+			err := actual.Set(test.input)
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSettings_SQLDialect(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   DBType
+		dialect  Dialect
+		expected DBType
+	}{
+		{
+			desc:     "database type is its own dialect",
+			dbType:   DBTypeMySQL,
+			expected: DBTypeMySQL,
+		},
+		{
+			desc:     "generic database type has the given dialect",
+			dbType:   DBTypeGeneric,
+			dialect:  Dialect(DBTypePostgresql),
+			expected: DBTypePostgresql,
+		},
+		{
+			desc:     "generic database type without dialect has none",
+			dbType:   DBTypeGeneric,
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.DbType = test.dbType
+			s.Dialect = test.dialect
+			assert.Equal(t, test.expected, s.SQLDialect())
+		})
+	}
+}
+
+func TestSettings_LogLevel(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *Settings
+		expected slog.Level
+	}{
+		{
+			desc:     "in default settings info is logged",
+			settings: New,
+			expected: slog.LevelInfo,
+		},
+		{
+			desc: "verbose mode logs debug",
+			settings: func() *Settings {
+				s := New()
+				s.Verbose = true
+				return s
+			},
+			expected: slog.LevelDebug,
+		},
+		{
+			desc: "v-verbose mode logs trace",
+			settings: func() *Settings {
+				s := New()
+				s.Verbose = true
+				s.VVerbose = true
+				return s
+			},
+			expected: LevelTrace,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := test.settings().LogLevel()
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSettings_NewLogger(t *testing.T) {
+	tests := []struct {
+		desc     string
+		format   LogFormat
+		expected string
+	}{
+		{
+			desc:     "text format",
+			format:   LogFormatText,
+			expected: "level=INFO msg=done table=users\n",
+		},
+		{
+			desc:     "json format",
+			format:   LogFormatJSON,
+			expected: `"level":"INFO","msg":"done","table":"users"}` + "\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New()
+			s.LogFormat = test.format
+
+			var buf bytes.Buffer
+			logger := s.NewLogger(&buf)
+			logger.Debug("processing", "table", "users")
+			logger.Info("done", "table", "users")
+
+			assert.Contains(t, buf.String(), test.expected)
+			assert.NotContains(t, buf.String(), "processing")
+		})
+	}
+}
+
+func TestSettings_Log(t *testing.T) {
+	var settings *Settings
+	assert.NotNil(t, settings.Log())
+	assert.False(t, New().Log().Enabled(context.Background(), slog.LevelError))
+
+	s := New()
+	s.Logger = slog.Default()
+	assert.Equal(t, slog.Default(), s.Log())
+}
+
+func TestStringMap_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inputs   []string
+		expected StringMap
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "single pair gets added",
+			inputs:   []string{"person=people"},
+			expected: StringMap{"person": "people"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "comma separated pairs of multiple calls get added",
+			inputs:   []string{"person=people, cactus = cacti", "datum=data"},
+			expected: StringMap{"person": "people", "cactus": "cacti", "datum": "data"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "pair without value produces error",
+			inputs:   []string{"person"},
+			expected: StringMap{},
+			isError:  assert.Error,
+		},
+		{
+			desc:     "pair without key produces error",
+			inputs:   []string{"=people"},
+			expected: StringMap{},
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var actual StringMap
+			var err error
+			for _, input := range test.inputs {
+				err = actual.Set(input)
+			}
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRawStringList_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inputs   []string
+		expected RawStringList
+	}{
+		{
+			desc:     "value with commas gets added as is",
+			inputs:   []string{`bson:"{{.Column.Name}},omitempty"`},
+			expected: RawStringList{`bson:"{{.Column.Name}},omitempty"`},
+		},
+		{
+			desc:     "values of multiple calls get appended",
+			inputs:   []string{`a:"{{.Column.Name}}"`, `b:"{{.Table.Name}}"`},
+			expected: RawStringList{`a:"{{.Column.Name}}"`, `b:"{{.Table.Name}}"`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var actual RawStringList
+			for _, input := range test.inputs {
+				err := actual.Set(input)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRawStringMap_Set(t *testing.T) {
+	tests := []struct {
+		desc     string
+		inputs   []string
+		expected RawStringMap
+		isError  assert.ErrorAssertionFunc
+	}{
+		{
+			desc:     "pair with commas in value gets added as is",
+			inputs:   []string{"users.email=required,email"},
+			expected: RawStringMap{"users.email": "required,email"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "pairs of multiple calls get added",
+			inputs:   []string{"users=-", "users.age=gte=0,lte=130"},
+			expected: RawStringMap{"users": "-", "users.age": "gte=0,lte=130"},
+			isError:  assert.NoError,
+		},
+		{
+			desc:     "pair without key produces error",
+			inputs:   []string{"=required"},
+			expected: RawStringMap{},
+			isError:  assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var actual RawStringMap
+			var err error
+			for _, input := range test.inputs {
+				err = actual.Set(input)
+			}
+			test.isError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRegisterDbType(t *testing.T) {
+	const dbType DBType = "test"
+	defer func() {
+		delete(dbDefaultPorts, dbType)
+	}()
+
+	RegisterDbType(dbType, "1234")
+
+	var actual DBType
+	assert.NoError(t, actual.Set(string(dbType)))
+	assert.Contains(t, SprintfSupportedDbTypes(), string(dbType))
+	assert.Contains(t, SupportedDbTypes(), dbType)
+
+	s := New()
+	s.DbType = dbType
+	assert.NoError(t, s.Verify())
+	assert.Equal(t, "1234", s.Port)
+}
+
 func TestSprintfSupportedDbTypes(t *testing.T) {
 	tests := []struct {
 		desc     string
@@ -425,7 +1065,7 @@ func TestSprintfSupportedDbTypes(t *testing.T) {
 	}{
 		{
 			desc:     "print all supported DB types",
-			expected: len(SupportedDbTypes),
+			expected: len(SupportedDbTypes()),
 		},
 	}
 	for _, test := range tests {
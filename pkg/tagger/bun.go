@@ -0,0 +1,44 @@
+package tagger
+
+import (
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// Bun represents the uptrace/bun "bun"-tag.
+type Bun struct{}
+
+// GenerateTag for Bun to satisfy the Tagger interface.
+func (t Bun) GenerateTag(db database.Database, column database.Column) string {
+
+	isPk := ""
+	if db.IsPrimaryKey(column) {
+		isPk = ",pk"
+	}
+
+	isAutoIncrement := ""
+	if db.IsAutoIncrement(column) {
+		isAutoIncrement = ",autoincrement"
+	}
+
+	isNotNull := ""
+	if !db.IsNullable(column) {
+		isNotNull = ",notnull"
+	}
+
+	return `bun:"` + column.Name + isPk + isAutoIncrement + isNotNull + `"`
+}
+
+// BunTableTag returns the bun-tag of the bun.BaseModel field with the table
+// name. Names containing separators of bun tags get single-quoted, and the
+// tag gets escaped to be a valid struct tag.
+func BunTableTag(tableName string) string {
+	value := tableName
+	if strings.ContainsAny(value, ",:'\\") {
+		value = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+	}
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+
+	return `bun:"table:` + value + `"`
+}
@@ -0,0 +1,147 @@
+package tagger
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestBun_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:   "nullable column has only the column name",
+			dbType: settings.DBTypePostgresql,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `bun:"column_name"`,
+		},
+		{
+			desc:   "NOT NULL column gets notnull as option",
+			dbType: settings.DBTypePostgresql,
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: `bun:"column_name,notnull"`,
+		},
+		{
+			desc:   "serial primary key of pg gets pk and autoincrement before notnull",
+			dbType: settings.DBTypePostgresql,
+			column: database.Column{
+				Name:           "id",
+				ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true},
+				DefaultValue:   sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+			},
+			expected: `bun:"id,pk,autoincrement,notnull"`,
+		},
+		{
+			desc:   "primary key of mysql without auto_increment gets no autoincrement",
+			dbType: settings.DBTypeMySQL,
+			column: database.Column{
+				Name:      "code",
+				ColumnKey: "PRI",
+			},
+			expected: `bun:"code,pk,notnull"`,
+		},
+		{
+			desc:   "auto_increment column of mysql without primary key gets no pk",
+			dbType: settings.DBTypeMySQL,
+			column: database.Column{
+				Name:       "seq",
+				Extra:      "auto_increment",
+				IsNullable: "YES",
+			},
+			expected: `bun:"seq,autoincrement"`,
+		},
+		{
+			desc:   "primary key of sqlite is an alias of the rowid and auto incremented",
+			dbType: settings.DBTypeSQLite,
+			column: database.Column{
+				Name:      "id",
+				DataType:  "INTEGER",
+				ColumnKey: "PK",
+				Extra:     "auto_increment",
+			},
+			expected: `bun:"id,pk,autoincrement,notnull"`,
+		},
+		{
+			desc:   "text primary key of sqlite is not auto incremented",
+			dbType: settings.DBTypeSQLite,
+			column: database.Column{
+				Name:      "token",
+				DataType:  "TEXT",
+				ColumnKey: "PK",
+			},
+			expected: `bun:"token,pk,notnull"`,
+		},
+	}
+
+	tagger := new(Bun)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			actual := tagger.GenerateTag(database.New(s), test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestBunTableTag(t *testing.T) {
+	tests := []struct {
+		desc      string
+		tableName string
+		expected  string
+		value     string
+	}{
+		{
+			desc:      "plain table name is not quoted",
+			tableName: "users",
+			expected:  `bun:"table:users"`,
+			value:     `table:users`,
+		},
+		{
+			desc:      "schema qualified table name is not quoted",
+			tableName: "public.users",
+			expected:  `bun:"table:public.users"`,
+			value:     `table:public.users`,
+		},
+		{
+			desc:      "table name with a separator of bun tags gets single-quoted",
+			tableName: "orders,2024",
+			expected:  `bun:"table:'orders,2024'"`,
+			value:     `table:'orders,2024'`,
+		},
+		{
+			desc:      "single quote gets escaped for bun and the backslash for the struct tag",
+			tableName: "user's",
+			expected:  `bun:"table:'user\\'s'"`,
+			value:     `table:'user\'s'`,
+		},
+		{
+			desc:      "double quote gets escaped for the struct tag",
+			tableName: `odd"name`,
+			expected:  `bun:"table:odd\"name"`,
+			value:     `table:odd"name`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := BunTableTag(test.tableName)
+			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.value, reflect.StructTag(actual).Get("bun"))
+		})
+	}
+}
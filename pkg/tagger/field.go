@@ -0,0 +1,82 @@
+package tagger
+
+import (
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Field describes the struct field a tag gets generated for.
+type Field struct {
+	// Table is the table the column belongs to.
+	Table *database.Table
+	// Column is the column the field originates from.
+	Column database.Column
+	// Name is the Go name of the field.
+	Name string
+	// Type is the Go type of the field, eg. `sql.NullString` or `*string`.
+	Type string
+	// Settings are the settings the field gets generated with.
+	Settings *settings.Settings
+}
+
+// IsPointer returns true if the type of the field is a pointer.
+func (f Field) IsPointer() bool {
+	return strings.HasPrefix(f.Type, "*")
+}
+
+// PrimaryKeys returns the names of the primary key columns of the table.
+func (f Field) PrimaryKeys(db database.Database) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, column := range f.Table.Columns {
+		if seen[column.Name] || !db.IsPrimaryKey(column) {
+			continue
+		}
+		seen[column.Name] = true
+		keys = append(keys, column.Name)
+	}
+	return keys
+}
+
+// IsCompositeKey returns true if the column is part of a primary key
+// consisting of multiple columns.
+func (f Field) IsCompositeKey(db database.Database) bool {
+	return db.IsPrimaryKey(f.Column) && len(f.PrimaryKeys(db)) > 1
+}
+
+// FieldTagger is a Tagger which generates the tag from the whole field
+// including its table, Go name and Go type.
+type FieldTagger interface {
+	GenerateFieldTag(db database.Database, field Field) string
+}
+
+// Adapt returns a FieldTagger for the given Tagger, which is called with the
+// column of the field only. FieldTaggers are returned as they are.
+func Adapt(tagger Tagger) FieldTagger {
+	if fieldTagger, ok := tagger.(FieldTagger); ok {
+		return fieldTagger
+	}
+	return adapter{tagger}
+}
+
+// adapter makes a Tagger usable as a FieldTagger.
+type adapter struct {
+	Tagger
+}
+
+// GenerateFieldTag for adapter to satisfy the FieldTagger interface.
+func (a adapter) GenerateFieldTag(db database.Database, field Field) string {
+	return a.GenerateTag(db, field.Column)
+}
+
+// columnField returns the field for the given column without table and Go
+// specifics, used by taggers called through the Tagger interface.
+func columnField(column database.Column) Field {
+	return Field{
+		Table:    &database.Table{},
+		Column:   column,
+		Settings: settings.New(),
+	}
+}
@@ -0,0 +1,105 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestField_IsCompositeKey(t *testing.T) {
+	pk := sql.NullString{String: "PRIMARY KEY", Valid: true}
+	id := database.Column{Name: "id", ConstraintType: pk}
+	orderID := database.Column{Name: "order_id", ConstraintType: pk}
+	itemID := database.Column{Name: "item_id", ConstraintType: pk}
+	name := database.Column{Name: "name"}
+
+	tests := []struct {
+		desc     string
+		table    *database.Table
+		column   database.Column
+		expected bool
+	}{
+		{
+			desc:     "single primary key column is no composite key",
+			table:    &database.Table{Columns: []database.Column{id, name}},
+			column:   id,
+			expected: false,
+		},
+		{
+			desc:     "primary key column of multiple is a composite key",
+			table:    &database.Table{Columns: []database.Column{orderID, itemID, name}},
+			column:   itemID,
+			expected: true,
+		},
+		{
+			desc:     "duplicated rows of a primary key column are no composite key",
+			table:    &database.Table{Columns: []database.Column{id, id, name}},
+			column:   id,
+			expected: false,
+		},
+		{
+			desc:     "non primary key column is no composite key",
+			table:    &database.Table{Columns: []database.Column{orderID, itemID, name}},
+			column:   name,
+			expected: false,
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			field := Field{Table: test.table, Column: test.column}
+			actual := field.IsCompositeKey(db)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	tests := []struct {
+		desc     string
+		tagger   Tagger
+		field    Field
+		expected string
+	}{
+		{
+			desc:   "Tagger gets called with the column",
+			tagger: new(Db),
+			field: Field{
+				Column: database.Column{Name: "column_name"},
+				Name:   "ColumnName",
+			},
+			expected: `db:"column_name"`,
+		},
+		{
+			desc:   "FieldTagger gets called with the field",
+			tagger: mustTemplate(t, `field:"{{.Field.Name}}"`),
+			field: Field{
+				Table:  &database.Table{},
+				Column: database.Column{Name: "column_name"},
+				Name:   "ColumnName",
+			},
+			expected: `field:"ColumnName"`,
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := Adapt(test.tagger).GenerateFieldTag(db, test.field)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func mustTemplate(t *testing.T, text string) *Template {
+	tmpl, err := NewTemplate(text)
+	assert.NoError(t, err)
+	return tmpl
+}
@@ -0,0 +1,56 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// Gorm represents the GORM "gorm"-tag.
+type Gorm struct{}
+
+// GenerateTag for Gorm to satisfy the Tagger interface.
+func (t Gorm) GenerateTag(db database.Database, column database.Column) string {
+
+	settings := []string{"column:" + column.Name}
+
+	if db.IsPrimaryKey(column) {
+		settings = append(settings, "primaryKey")
+	}
+
+	isAutoIncrement := db.IsAutoIncrement(column)
+	if isAutoIncrement {
+		settings = append(settings, "autoIncrement")
+	}
+
+	if column.DataType != "" {
+		settings = append(settings, "type:"+column.DataType)
+	}
+
+	if column.CharacterMaximumLength.Valid {
+		settings = append(settings, "size:"+strconv.FormatInt(column.CharacterMaximumLength.Int64, 10))
+	}
+
+	if !db.IsNullable(column) {
+		settings = append(settings, "not null")
+	}
+
+	// The default of an auto increment column is the sequence which is
+	// handled by the database. Semicolons would break the tag settings
+	// and backquotes the struct tag itself.
+	if column.DefaultValue.Valid && !isAutoIncrement &&
+		!strings.ContainsAny(column.DefaultValue.String, ";`") {
+		settings = append(settings, "default:"+column.DefaultValue.String)
+	}
+
+	if database.IsUnique(db, column) {
+		uniqueIndex := "uniqueIndex"
+		if column.ConstraintName.Valid {
+			uniqueIndex += ":" + column.ConstraintName.String
+		}
+		settings = append(settings, uniqueIndex)
+	}
+
+	return `gorm:` + strconv.Quote(strings.Join(settings, ";"))
+}
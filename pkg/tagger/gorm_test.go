@@ -0,0 +1,148 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestGorm_GenerateTag(t *testing.T) {
+	type test struct {
+		desc     string
+		column   database.Column
+		expected string
+	}
+
+	tests := map[settings.DBType][]test{
+		settings.DBTypePostgresql: {
+			{
+				desc: "nullable column generates only column name and type",
+				column: database.Column{
+					Name:       "column_name",
+					DataType:   "text",
+					IsNullable: "YES",
+				},
+				expected: `gorm:"column:column_name;type:text"`,
+			},
+			{
+				desc: "PK and AI column generates GORM-tag with PK and AI indicator without default",
+				column: database.Column{
+					Name:     "id",
+					DataType: "integer",
+					ConstraintType: sql.NullString{
+						String: "PRIMARY KEY",
+						Valid:  true,
+					},
+					DefaultValue: sql.NullString{
+						String: "nextval('users_id_seq'::regclass)",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:id;primaryKey;autoIncrement;type:integer;not null"`,
+			},
+			{
+				desc: "unique varchar column with default generates GORM-tag with size, default and unique index",
+				column: database.Column{
+					Name:     "email",
+					DataType: "character varying",
+					CharacterMaximumLength: sql.NullInt64{
+						Int64: 255,
+						Valid: true,
+					},
+					DefaultValue: sql.NullString{
+						String: "''::character varying",
+						Valid:  true,
+					},
+					ConstraintName: sql.NullString{
+						String: "users_email_key",
+						Valid:  true,
+					},
+					ConstraintType: sql.NullString{
+						String: "UNIQUE",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:email;type:character varying;size:255;not null;default:''::character varying;uniqueIndex:users_email_key"`,
+			},
+			{
+				desc: "default with semicolon is omitted",
+				column: database.Column{
+					Name:     "note",
+					DataType: "text",
+					DefaultValue: sql.NullString{
+						String: "'a;b'::text",
+						Valid:  true,
+					},
+				},
+				expected: `gorm:"column:note;type:text;not null"`,
+			},
+		},
+		settings.DBTypeMySQL: {
+			{
+				desc: "PK and AI column generates GORM-tag with PK and AI indicator",
+				column: database.Column{
+					Name:      "id",
+					DataType:  "int",
+					ColumnKey: "PRI",
+					Extra:     "auto_increment",
+				},
+				expected: `gorm:"column:id;primaryKey;autoIncrement;type:int;not null"`,
+			},
+			{
+				desc: "unique column generates GORM-tag with unnamed unique index",
+				column: database.Column{
+					Name:      "email",
+					DataType:  "varchar",
+					ColumnKey: "UNI",
+					CharacterMaximumLength: sql.NullInt64{
+						Int64: 100,
+						Valid: true,
+					},
+				},
+				expected: `gorm:"column:email;type:varchar;size:100;not null;uniqueIndex"`,
+			},
+		},
+		settings.DBTypeSQLite: {
+			{
+				desc: "PK column generates GORM-tag with PK and AI indicator",
+				column: database.Column{
+					Name:      "id",
+					DataType:  "integer",
+					ColumnKey: "PK",
+					Extra:     "auto_increment",
+				},
+				expected: `gorm:"column:id;primaryKey;autoIncrement;type:integer;not null"`,
+			},
+			{
+				desc: "PK column which is no rowid generates GORM-tag with PK indicator",
+				column: database.Column{
+					Name:      "token",
+					DataType:  "text",
+					ColumnKey: "PK",
+				},
+				expected: `gorm:"column:token;primaryKey;type:text;not null"`,
+			},
+		},
+	}
+
+	tagger := new(Gorm)
+
+	for _, dbType := range settings.SupportedDbTypes() {
+		t.Run(dbType.String(), func(t *testing.T) {
+			tests := tests[dbType]
+			for _, test := range tests {
+				t.Run(test.desc, func(t *testing.T) {
+					s := settings.New()
+					s.DbType = dbType
+					db := database.New(s)
+					actual := tagger.GenerateTag(db, test.column)
+					assert.Equal(t, test.expected, actual)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package tagger
+
+import (
+	"github.com/iancoleman/strcase"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// JSON represents the standard "json"-tag.
+type JSON struct {
+	Naming    settings.JSONNaming
+	OmitEmpty settings.OmitEmpty
+}
+
+// GenerateTag for JSON to satisfy the Tagger interface.
+func (t JSON) GenerateTag(db database.Database, column database.Column) string {
+
+	name := column.Name
+	switch t.Naming {
+	case settings.JSONNamingSnakeCase:
+		name = strcase.ToSnake(name)
+	case settings.JSONNamingCamelCase:
+		name = strcase.ToLowerCamel(name)
+	}
+
+	omitEmpty := ""
+	if t.OmitEmpty == settings.OmitEmptyAlways ||
+		t.OmitEmpty == settings.OmitEmptyNullable && db.IsNullable(column) {
+		omitEmpty = ",omitempty"
+	}
+
+	return `json:"` + name + omitEmpty + `"`
+}
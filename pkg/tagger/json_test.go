@@ -0,0 +1,94 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestJSON_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc      string
+		naming    settings.JSONNaming
+		omitEmpty settings.OmitEmpty
+		column    database.Column
+		expected  string
+	}{
+		{
+			desc:   "original naming keeps the column name",
+			naming: settings.JSONNamingOriginal,
+			column: database.Column{
+				Name: "userId",
+			},
+			expected: `json:"userId"`,
+		},
+		{
+			desc:   "snake case naming converts the column name",
+			naming: settings.JSONNamingSnakeCase,
+			column: database.Column{
+				Name: "userId",
+			},
+			expected: `json:"user_id"`,
+		},
+		{
+			desc:   "camel case naming converts the column name",
+			naming: settings.JSONNamingCamelCase,
+			column: database.Column{
+				Name: "user_id",
+			},
+			expected: `json:"userId"`,
+		},
+		{
+			desc:      "never omitempty policy omits option on nullable column",
+			omitEmpty: settings.OmitEmptyNever,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `json:"column_name"`,
+		},
+		{
+			desc:      "nullable omitempty policy adds option on nullable column",
+			omitEmpty: settings.OmitEmptyNullable,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `json:"column_name,omitempty"`,
+		},
+		{
+			desc:      "nullable omitempty policy omits option on NOT NULL column",
+			omitEmpty: settings.OmitEmptyNullable,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "NO",
+			},
+			expected: `json:"column_name"`,
+		},
+		{
+			desc:      "always omitempty policy adds option on NOT NULL column",
+			omitEmpty: settings.OmitEmptyAlways,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "NO",
+			},
+			expected: `json:"column_name,omitempty"`,
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tagger := JSON{
+				Naming:    test.naming,
+				OmitEmpty: test.omitEmpty,
+			}
+			actual := tagger.GenerateTag(db, test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
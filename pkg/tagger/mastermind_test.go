@@ -148,16 +148,34 @@ func TestMastermind_GenerateTag(t *testing.T) {
 				},
 				column: database.Column{
 					Name:      "column_name",
+					DataType:  "INTEGER",
 					ColumnKey: "PK",
+					Extra:     "auto_increment",
 				},
 				expected: `stbl:"column_name,PRIMARY_KEY,SERIAL,AUTO_INCREMENT"`,
 			},
+			{
+				desc: "PK column which is no rowid generates Mastermind-tag with PK indicator",
+				settings: func() *settings.Settings {
+					s := settings.New()
+					s.DbType = settings.DBTypeSQLite
+					s.TagsNoDb = true
+					s.TagsMastermindStructable = true
+					return s
+				},
+				column: database.Column{
+					Name:      "column_name",
+					DataType:  "TEXT",
+					ColumnKey: "PK",
+				},
+				expected: `stbl:"column_name,PRIMARY_KEY"`,
+			},
 		},
 	}
 
 	tagger := new(Mastermind)
 
-	for dbType := range settings.SupportedDbTypes {
+	for _, dbType := range settings.SupportedDbTypes() {
 		t.Run(dbType.String(), func(t *testing.T) {
 			tests := tests[dbType]
 			for _, test := range tests {
@@ -1,11 +1,13 @@
 package tagger
 
 import (
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/fraenky8/tables-to-go/pkg/database"
 	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/typemapper"
 )
 
 const (
@@ -14,6 +16,11 @@ const (
 	// number is an ascending sequence of i*2 to determine which tags to generate later
 	tagDb         = 1
 	tagMastermind = 2
+	tagGorm       = 4
+	tagJSON       = 8
+	tagValidate   = 16
+	tagBun        = 32
+	tagXorm       = 64
 )
 
 var stringPool = sync.Pool{
@@ -33,9 +40,16 @@ type Taggers struct {
 
 	enabledTags int
 	taggers     map[int]Tagger
+
+	// registered are additional taggers generated after the enabled ones
+	registered []FieldTagger
 }
 
-// NewTaggers is the constructor function to create the supported taggers.
+// NewTaggers is the constructor function to create the supported taggers. The
+// tag templates of the settings are ignored.
+//
+// Deprecated: Use NewTaggersWithTemplates, which also registers the tag
+// templates of the settings.
 func NewTaggers(s *settings.Settings) *Taggers {
 	t := &Taggers{
 		settings:    s,
@@ -43,6 +57,17 @@ func NewTaggers(s *settings.Settings) *Taggers {
 		taggers: map[int]Tagger{
 			tagDb:         new(Db),
 			tagMastermind: new(Mastermind),
+			tagGorm:       new(Gorm),
+			tagJSON: &JSON{
+				Naming:    s.JSONNaming,
+				OmitEmpty: s.JSONOmitEmpty,
+			},
+			tagValidate: &Validator{
+				Rules: s.ValidateRules,
+				Types: typemapper.Default(s.DbType, s.Null),
+			},
+			tagBun:  new(Bun),
+			tagXorm: new(Xorm),
 		},
 	}
 
@@ -51,6 +76,39 @@ func NewTaggers(s *settings.Settings) *Taggers {
 	return t
 }
 
+// NewTaggersWithTemplates creates the supported taggers like NewTaggers and
+// registers the tag templates of the settings as additional taggers. It
+// returns an error if a tag template can't be parsed or executed for a sample
+// column.
+func NewTaggersWithTemplates(s *settings.Settings) (*Taggers, error) {
+	t := NewTaggers(s)
+
+	for _, text := range s.TagTemplates {
+		tmpl, err := NewTemplate(text)
+		if err != nil {
+			return nil, err
+		}
+		if err = tmpl.check(s); err != nil {
+			return nil, err
+		}
+		t.Register(tmpl)
+	}
+
+	return t, nil
+}
+
+// Register adds the given tagger to generate its tag after the enabled ones,
+// in the order of registration. A Tagger can be registered through Adapt.
+func (t *Taggers) Register(tagger FieldTagger) {
+	t.registered = append(t.registered, tagger)
+}
+
+// SetTypeMapper sets the type mapper classifying the columns for the tags
+// derived from their data types.
+func (t *Taggers) SetTypeMapper(mapper typemapper.TypeMapper) {
+	t.taggers[tagValidate].(*Validator).Types = mapper
+}
+
 // enableTags enables the tags to generate as given by the settings.
 // If multiple, standalone tags where specified (the ones with "only" in their names),
 // the last specified standalone tag wins.
@@ -65,25 +123,66 @@ func (t *Taggers) enableTags() {
 		t.enabledTags = tagsDisabled
 		t.enabledTags |= tagMastermind
 	}
+	if t.settings.TagsGorm {
+		t.enabledTags |= tagGorm
+	}
+	if t.settings.TagsJSON {
+		t.enabledTags |= tagJSON
+	}
+	if t.settings.TagsValidate {
+		t.enabledTags |= tagValidate
+	}
+	if t.settings.TagsBun {
+		t.enabledTags |= tagBun
+	}
+	if t.settings.TagsXorm {
+		t.enabledTags |= tagXorm
+	}
 }
 
 // GenerateTag creates based on the enabled tags and the given database and column
 // the tag for the struct field.
 func (t *Taggers) GenerateTag(db database.Database, column database.Column) (tags string) {
+	field := columnField(column)
+	field.Settings = t.settings
+	return t.GenerateFieldTag(db, field)
+}
+
+// GenerateFieldTag creates based on the enabled tags and the given database
+// and field the tag for the struct field. The tags are ordered by their keys
+// as given by the settings, the remaining ones follow in the default order.
+func (t *Taggers) GenerateFieldTag(db database.Database, field Field) (tags string) {
 	sb := stringPool.Get().(*strings.Builder)
 	defer func() {
 		sb.Reset()
 		stringPool.Put(sb)
 	}()
 
+	taggers := make([]FieldTagger, 0, len(t.taggers)+len(t.registered))
 	for bit := 1; bit <= t.enabledTags; bit *= 2 {
 		shouldTag := t.enabledTags&bit > 0
 		if shouldTag {
-			sb.WriteString(t.taggers[bit].GenerateTag(db, column))
-			sb.WriteString(" ")
+			taggers = append(taggers, Adapt(t.taggers[bit]))
+		}
+	}
+	taggers = append(taggers, t.registered...)
+
+	generated := make([]string, 0, len(taggers))
+	for _, tagger := range taggers {
+		if tag := tagger.GenerateFieldTag(db, field); tag != "" {
+			generated = append(generated, tag)
 		}
 	}
 
+	sort.SliceStable(generated, func(i, j int) bool {
+		return t.rank(generated[i]) < t.rank(generated[j])
+	})
+
+	for _, tag := range generated {
+		sb.WriteString(tag)
+		sb.WriteString(" ")
+	}
+
 	tags = sb.String()
 
 	if len(tags) > 0 {
@@ -92,3 +191,15 @@ func (t *Taggers) GenerateTag(db database.Database, column database.Column) (tag
 
 	return tags
 }
+
+// rank returns the position of the key of the tag in the tag order of the
+// settings. Keys not contained are ranked after all the contained ones.
+func (t *Taggers) rank(tag string) int {
+	key, _, _ := strings.Cut(tag, ":")
+	for i, k := range t.settings.TagOrder {
+		if k == key {
+			return i
+		}
+	}
+	return len(t.settings.TagOrder)
+}
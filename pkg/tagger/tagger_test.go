@@ -77,14 +77,169 @@ func TestTaggers_GenerateTags(t *testing.T) {
 			},
 			expected: "`stbl:\"column_name\"`",
 		},
+		{
+			desc: "default db-tag with enabled GORM-tag creates db- and GORM-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNoDb = false
+				s.TagsGorm = true
+				return s
+			},
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: "`db:\"column_name\" gorm:\"column:column_name\"`",
+		},
+		{
+			desc: "disabled db-tag with enabled standalone Mastermind-tag and JSON-tag creates Mastermind- and JSON-tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsNoDb = true
+				s.TagsMastermindStructableOnly = true
+				s.TagsJSON = true
+				s.JSONNaming = settings.JSONNamingCamelCase
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`stbl:\"column_name\" json:\"columnName\"`",
+		},
+		{
+			desc: "default db-tag with enabled validate-tag without rules creates only db-tag",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsValidate = true
+				return s
+			},
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: "`db:\"column_name\"`",
+		},
+		{
+			desc: "default db-tag with enabled validate-tag and JSON-tag creates all tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsValidate = true
+				return s
+			},
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "text",
+			},
+			expected: "`db:\"column_name\" json:\"column_name\" validate:\"required\"`",
+		},
+		{
+			desc: "default db-tag with tag templates creates db-tag followed by templated tags",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagTemplates = settings.RawStringList{
+					`mapstructure:"{{.Column.Name}}"`,
+					`bson:"{{camel .Column.Name}},omitempty"`,
+				}
+				return s
+			},
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "`db:\"column_name\" mapstructure:\"column_name\" bson:\"ColumnName,omitempty\"`",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
 			s := test.settings()
-			taggers := NewTaggers(s)
+			taggers, err := NewTaggersWithTemplates(s)
+			assert.NoError(t, err)
 			db := database.New(s)
 			actual := taggers.GenerateTag(db, test.column)
 			assert.Equal(t, test.expected, actual)
 		})
 	}
 }
+
+// fieldNameTagger tags with the Go name of the field.
+type fieldNameTagger struct{}
+
+func (fieldNameTagger) GenerateFieldTag(_ database.Database, field Field) string {
+	return `field:"` + field.Name + `"`
+}
+
+func TestTaggers_GenerateFieldTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		settings func() *settings.Settings
+		expected string
+	}{
+		{
+			desc: "tags get generated in default order",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsMastermindStructable = true
+				return s
+			},
+			expected: "`db:\"column_name\" stbl:\"column_name\" json:\"column_name\" field:\"ColumnName\"`",
+		},
+		{
+			desc: "tags get generated in configured order, remaining ones in default order",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagsJSON = true
+				s.TagsMastermindStructable = true
+				s.TagOrder = settings.StringList{"field", "json"}
+				return s
+			},
+			expected: "`field:\"ColumnName\" json:\"column_name\" db:\"column_name\" stbl:\"column_name\"`",
+		},
+		{
+			desc: "unknown keys in configured order are ignored",
+			settings: func() *settings.Settings {
+				s := settings.New()
+				s.TagOrder = settings.StringList{"unknown", "field"}
+				return s
+			},
+			expected: "`field:\"ColumnName\" db:\"column_name\"`",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := test.settings()
+			taggers := NewTaggers(s)
+			taggers.Register(fieldNameTagger{})
+			db := database.New(s)
+			actual := taggers.GenerateFieldTag(db, Field{
+				Table:    &database.Table{Name: "test_table"},
+				Column:   database.Column{Name: "column_name"},
+				Name:     "ColumnName",
+				Type:     "string",
+				Settings: s,
+			})
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNewTaggersWithTemplates(t *testing.T) {
+	s := settings.New()
+	s.TagTemplates = settings.RawStringList{`bson:"{{.Column.Name}}"`}
+	db := database.New(s)
+	column := database.Column{Name: "column_name"}
+
+	taggers, err := NewTaggersWithTemplates(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "`db:\"column_name\" bson:\"column_name\"`", taggers.GenerateTag(db, column))
+
+	assert.Equal(t, "`db:\"column_name\"`", NewTaggers(s).GenerateTag(db, column))
+
+	s.TagTemplates = settings.RawStringList{`bson:"{{.Column.Name"`}
+	_, err = NewTaggersWithTemplates(s)
+	assert.Error(t, err)
+
+	s.TagTemplates = settings.RawStringList{`bson:"{{.Column.Nmae}}"`}
+	_, err = NewTaggersWithTemplates(s)
+	assert.ErrorContains(t, err, "could not execute tag template")
+}
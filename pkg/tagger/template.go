@@ -0,0 +1,99 @@
+package tagger
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// templateFuncs are the helper functions available in tag templates.
+var templateFuncs = template.FuncMap{
+	"snake":      strcase.ToSnake,
+	"camel":      strcase.ToCamel,
+	"lowerCamel": strcase.ToLowerCamel,
+	"kebab":      strcase.ToKebab,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+}
+
+// TemplateData is the data a tag template gets executed with.
+type TemplateData struct {
+	DB     database.Database
+	Table  *database.Table
+	Column database.Column
+	Field  Field
+}
+
+// Template represents a user-defined tag given as text/template, eg.
+// `bson:"{{snake .Column.Name}},omitempty"`.
+type Template struct {
+	text     string
+	template *template.Template
+}
+
+// NewTemplate parses the given text to a Template.
+func NewTemplate(text string) (*Template, error) {
+	tmpl, err := template.New("tag").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse tag template %q: %w", text, err)
+	}
+	return &Template{
+		text:     text,
+		template: tmpl,
+	}, nil
+}
+
+// GenerateTag for Template to satisfy the Tagger interface. Without a table,
+// the template gets executed with an empty table.
+func (t Template) GenerateTag(db database.Database, column database.Column) string {
+	return t.GenerateFieldTag(db, columnField(column))
+}
+
+// GenerateFieldTag for Template to satisfy the FieldTagger interface. If the
+// template can't be executed for the column, the tag is omitted.
+func (t Template) GenerateFieldTag(db database.Database, field Field) string {
+	tag, err := t.execute(db, field)
+	if err != nil {
+		field.Settings.Log().Warn("could not execute tag template", "template", t.text,
+			"column", field.Column.Name, "table", field.Table.Name, "error", err)
+		return ""
+	}
+	return tag
+}
+
+// check executes the template with a sample column of the database type of
+// the settings, to detect errors like unknown fields before generating.
+func (t Template) check(s *settings.Settings) error {
+	column := database.Column{OrdinalPosition: 1, Name: "column_name", DataType: "text", IsNullable: "NO"}
+	_, err := t.execute(database.New(s), Field{
+		Table:    &database.Table{Name: "table_name", Columns: []database.Column{column}},
+		Column:   column,
+		Name:     "ColumnName",
+		Type:     "string",
+		Settings: s,
+	})
+	if err != nil {
+		return fmt.Errorf("could not execute tag template %q: %w", t.text, err)
+	}
+	return nil
+}
+
+// execute executes the template for the field and returns the trimmed tag.
+func (t Template) execute(db database.Database, field Field) (string, error) {
+	var sb strings.Builder
+	err := t.template.Execute(&sb, TemplateData{
+		DB:     db,
+		Table:  field.Table,
+		Column: field.Column,
+		Field:  field,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
@@ -0,0 +1,129 @@
+package tagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestNewTemplate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		text    string
+		isError assert.ErrorAssertionFunc
+	}{
+		{
+			desc:    "valid template gets parsed",
+			text:    `mapstructure:"{{.Column.Name}}"`,
+			isError: assert.NoError,
+		},
+		{
+			desc:    "unclosed action produces error",
+			text:    `mapstructure:"{{.Column.Name"`,
+			isError: assert.Error,
+		},
+		{
+			desc:    "unknown function produces error",
+			text:    `mapstructure:"{{unknown .Column.Name}}"`,
+			isError: assert.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewTemplate(test.text)
+			test.isError(t, err)
+		})
+	}
+}
+
+func TestTemplate_GenerateFieldTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		text     string
+		table    *database.Table
+		column   database.Column
+		name     string
+		typ      string
+		expected string
+	}{
+		{
+			desc: "column name gets inserted",
+			text: `mapstructure:"{{.Column.Name}}"`,
+			column: database.Column{
+				Name: "userId",
+			},
+			expected: `mapstructure:"userId"`,
+		},
+		{
+			desc: "helper functions convert the column name",
+			text: `bson:"{{snake .Column.Name}},omitempty" yaml:"{{kebab .Column.Name}}"`,
+			column: database.Column{
+				Name: "userId",
+			},
+			expected: `bson:"user_id,omitempty" yaml:"user-id"`,
+		},
+		{
+			desc:  "table name gets inserted",
+			text:  `ref:"{{.Table.Name}}.{{.Column.Name}}"`,
+			table: &database.Table{Name: "users"},
+			column: database.Column{
+				Name: "id",
+			},
+			expected: `ref:"users.id"`,
+		},
+		{
+			desc: "database helpers can be used",
+			text: `{{if .DB.IsNullable .Column}}nullable:"true"{{end}}`,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `nullable:"true"`,
+		},
+		{
+			desc: "field name and type can be used",
+			text: `{{if .Field.IsPointer}}ptr:"{{.Field.Name}}"{{end}}`,
+			column: database.Column{
+				Name: "user_id",
+			},
+			name:     "UserID",
+			typ:      "*int",
+			expected: `ptr:"UserID"`,
+		},
+		{
+			desc: "empty result produces no tag",
+			text: `{{if .DB.IsNullable .Column}}nullable:"true"{{end}}`,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "NO",
+			},
+			expected: "",
+		},
+		{
+			desc: "execution error produces no tag",
+			text: `x:"{{.Column.Unknown}}"`,
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: "",
+		},
+	}
+
+	db := database.New(settings.New())
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tmpl, err := NewTemplate(test.text)
+			assert.NoError(t, err)
+			table := test.table
+			if table == nil {
+				table = &database.Table{}
+			}
+			actual := tmpl.GenerateFieldTag(db, Field{Table: table, Column: test.column, Name: test.name, Type: test.typ})
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
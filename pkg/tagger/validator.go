@@ -0,0 +1,123 @@
+package tagger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/typemapper"
+)
+
+// Validator represents the go-playground/validator "validate"-tag. The rules
+// are derived from the constraints of the column unless they are configured
+// for the table or column explicitly.
+type Validator struct {
+	// Rules maps table and table.column names to rules which replace the
+	// derived ones. The rule "-" omits the tag.
+	Rules map[string]string
+	// Types classifies the columns to derive the rules. If nil, the columns
+	// are classified like the ones of the generic database type.
+	Types typemapper.TypeMapper
+}
+
+// GenerateTag for Validator to satisfy the Tagger interface. Without a table,
+// only the derived rules are generated.
+func (t Validator) GenerateTag(db database.Database, column database.Column) string {
+	return t.GenerateFieldTag(db, columnField(column))
+}
+
+// GenerateFieldTag for Validator to satisfy the FieldTagger interface.
+func (t Validator) GenerateFieldTag(db database.Database, field Field) string {
+	table, column := field.Table, field.Column
+
+	rules, ok := t.Rules[table.Name+"."+column.Name]
+	if !ok {
+		rules, ok = t.Rules[table.Name]
+	}
+	if !ok {
+		rules = strings.Join(deriveValidateRules(db, column, t.kind(column)), ",")
+	}
+
+	if rules == "" || rules == "-" {
+		return ""
+	}
+
+	return `validate:"` + rules + `"`
+}
+
+// kind returns the kind of the data type of the column.
+func (t Validator) kind(column database.Column) typemapper.Kind {
+	types := t.Types
+	if types == nil {
+		types = typemapper.Default(settings.DBTypeGeneric, settings.NullTypeSQL)
+	}
+	mapped, _ := types.MapType(column)
+	return mapped.Kind
+}
+
+// deriveValidateRules derives the rules from NOT NULL, length, precision and
+// enum constraints of the column. The rules of nullable columns start with
+// omitempty, so NULL passes the validation.
+func deriveValidateRules(db database.Database, column database.Column, kind typemapper.Kind) []string {
+	var rules []string
+
+	isString := kind == typemapper.KindString || kind == typemapper.KindText
+	isEnum := len(column.EnumLabels()) > 0
+
+	// Only strings and enums are required, as the zero values of numbers,
+	// times and bools are valid values. Columns with a default or auto
+	// increment get their value by the database.
+	if (isString || isEnum) && !db.IsNullable(column) && !column.DefaultValue.Valid && !db.IsAutoIncrement(column) {
+		rules = append(rules, "required")
+	}
+
+	if isString && column.CharacterMaximumLength.Valid {
+		rules = append(rules, "max="+strconv.FormatInt(column.CharacterMaximumLength.Int64, 10))
+	}
+
+	if isDecimal(column) && column.NumericPrecision.Valid {
+		digits := column.NumericPrecision.Int64 - column.NumericScale.Int64
+		if digits >= 0 {
+			limit := "1" + strings.Repeat("0", int(digits))
+			rules = append(rules, "gt=-"+limit, "lt="+limit)
+		}
+	}
+
+	if labels, ok := oneOfLabels(column.EnumLabels()); ok {
+		rules = append(rules, "oneof="+strings.Join(labels, " "))
+	}
+
+	if len(rules) > 0 && db.IsNullable(column) {
+		rules = append([]string{"omitempty"}, rules...)
+	}
+
+	return rules
+}
+
+// oneOfLabels returns the enum labels as parameters of the oneof rule, quoted
+// if they contain spaces. It returns false if there are no labels or a label
+// can't be expressed in the rule or struct tag, eg. one containing a comma.
+func oneOfLabels(labels []string) ([]string, bool) {
+	if len(labels) == 0 {
+		return nil, false
+	}
+
+	params := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label == "" || strings.ContainsAny(label, "',|\"`\\") {
+			return nil, false
+		}
+		if strings.ContainsAny(label, " ") {
+			label = "'" + label + "'"
+		}
+		params = append(params, label)
+	}
+	return params, true
+}
+
+// isDecimal returns true if the column is a fixed-point number whose
+// precision is given in decimal digits.
+func isDecimal(column database.Column) bool {
+	return column.DataType == "numeric" || column.DataType == "decimal"
+}
@@ -0,0 +1,215 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+	"github.com/fraenky8/tables-to-go/pkg/typemapper"
+)
+
+func TestValidator_GenerateFieldTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		rules    map[string]string
+		column   database.Column
+		expected string
+	}{
+		{
+			desc: "nullable column without constraints generates no tag",
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "integer",
+				IsNullable: "YES",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL text column without default is required",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "text",
+			},
+			expected: `validate:"required"`,
+		},
+		{
+			desc: "NOT NULL integer column is not required",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "integer",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL numeric column is not required",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "numeric",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL timestamp column is not required",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "timestamp",
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL column with default is not required",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "text",
+				DefaultValue: sql.NullString{
+					String: "0",
+					Valid:  true,
+				},
+			},
+			expected: "",
+		},
+		{
+			desc: "NOT NULL boolean column is not required",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "boolean",
+			},
+			expected: "",
+		},
+		{
+			desc: "varchar column generates max length",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "character varying",
+				CharacterMaximumLength: sql.NullInt64{
+					Int64: 20,
+					Valid: true,
+				},
+			},
+			expected: `validate:"required,max=20"`,
+		},
+		{
+			desc: "numeric column generates range from precision and scale",
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "numeric",
+				IsNullable: "YES",
+				NumericPrecision: sql.NullInt64{
+					Int64: 5,
+					Valid: true,
+				},
+				NumericScale: sql.NullInt64{
+					Int64: 2,
+					Valid: true,
+				},
+			},
+			expected: `validate:"omitempty,gt=-1000,lt=1000"`,
+		},
+		{
+			desc: "enum column generates oneof with quoted labels containing spaces",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "USER-DEFINED",
+				EnumValues: sql.NullString{
+					String: "'new','in progress','done'",
+					Valid:  true,
+				},
+			},
+			expected: `validate:"required,oneof=new 'in progress' done"`,
+		},
+		{
+			desc: "nullable enum column generates oneof after omitempty",
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "enum",
+				IsNullable: "YES",
+				EnumValues: sql.NullString{
+					String: "'small','large'",
+					Valid:  true,
+				},
+			},
+			expected: `validate:"omitempty,oneof=small large"`,
+		},
+		{
+			desc: "enum labels containing commas or quotes generate no oneof",
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "enum",
+				EnumValues: sql.NullString{
+					String: "'a,b','it''s'",
+					Valid:  true,
+				},
+			},
+			expected: `validate:"required"`,
+		},
+		{
+			desc:  "configured column rules replace the derived ones",
+			rules: map[string]string{"test_table.column_name": "required,email"},
+			column: database.Column{
+				Name:       "column_name",
+				DataType:   "text",
+				IsNullable: "YES",
+			},
+			expected: `validate:"required,email"`,
+		},
+		{
+			desc: "configured column rules take precedence over table rules",
+			rules: map[string]string{
+				"test_table":             "-",
+				"test_table.column_name": "uuid",
+			},
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "text",
+			},
+			expected: `validate:"uuid"`,
+		},
+		{
+			desc:  "configured table rules omitting the tag generate no tag",
+			rules: map[string]string{"test_table": "-"},
+			column: database.Column{
+				Name:     "column_name",
+				DataType: "text",
+			},
+			expected: "",
+		},
+	}
+
+	db := database.New(settings.New())
+	table := &database.Table{Name: "test_table"}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tagger := Validator{Rules: test.rules}
+			actual := tagger.GenerateFieldTag(db, Field{Table: table, Column: test.column})
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestValidator_Types(t *testing.T) {
+	db := database.New(settings.New())
+	column := database.Column{
+		Name:                   "column_name",
+		DataType:               "varbinary",
+		IsNullable:             "YES",
+		CharacterMaximumLength: sql.NullInt64{Int64: 16, Valid: true},
+	}
+
+	tagger := Validator{}
+	assert.Equal(t, "", tagger.GenerateTag(db, column))
+
+	tagger.Types = typemapper.Default(settings.DBTypeMySQL, settings.NullTypeSQL)
+	assert.Equal(t, `validate:"omitempty,max=16"`, tagger.GenerateTag(db, column))
+
+	// A NOT NULL boolean of a data type classified by the types isn't
+	// required, eg. tinyint(1) of MySQL.
+	tagger.Types = typemapper.Chain(
+		typemapper.DataTypes{"tinyint": typemapper.ForKind(typemapper.KindBool, settings.NullTypeSQL)},
+		typemapper.Default(settings.DBTypeMySQL, settings.NullTypeSQL),
+	)
+	assert.Equal(t, "", tagger.GenerateTag(db, database.Column{Name: "column_name", DataType: "tinyint"}))
+}
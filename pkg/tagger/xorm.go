@@ -0,0 +1,32 @@
+package tagger
+
+import (
+	"strings"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+)
+
+// Xorm represents the xorm "xorm"-tag.
+type Xorm struct{}
+
+// GenerateTag for Xorm to satisfy the Tagger interface.
+func (t Xorm) GenerateTag(db database.Database, column database.Column) string {
+
+	var options []string
+
+	if db.IsPrimaryKey(column) {
+		options = append(options, "pk")
+	}
+
+	if db.IsAutoIncrement(column) {
+		options = append(options, "autoincr")
+	}
+
+	if !db.IsNullable(column) {
+		options = append(options, "notnull")
+	}
+
+	options = append(options, "'"+column.Name+"'")
+
+	return `xorm:"` + strings.Join(options, " ") + `"`
+}
@@ -0,0 +1,87 @@
+package tagger
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestXorm_GenerateTag(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		column   database.Column
+		expected string
+	}{
+		{
+			desc:   "nullable column has only the single-quoted column name",
+			dbType: settings.DBTypePostgresql,
+			column: database.Column{
+				Name:       "column_name",
+				IsNullable: "YES",
+			},
+			expected: `xorm:"'column_name'"`,
+		},
+		{
+			desc:   "NOT NULL column gets notnull before the column name",
+			dbType: settings.DBTypePostgresql,
+			column: database.Column{
+				Name: "column_name",
+			},
+			expected: `xorm:"notnull 'column_name'"`,
+		},
+		{
+			desc:   "serial primary key of pg gets pk before autoincr",
+			dbType: settings.DBTypePostgresql,
+			column: database.Column{
+				Name:           "id",
+				ConstraintType: sql.NullString{String: "PRIMARY KEY", Valid: true},
+				DefaultValue:   sql.NullString{String: "nextval('users_id_seq'::regclass)", Valid: true},
+			},
+			expected: `xorm:"pk autoincr notnull 'id'"`,
+		},
+		{
+			desc:   "primary key of mysql without auto_increment gets no autoincr",
+			dbType: settings.DBTypeMySQL,
+			column: database.Column{
+				Name:      "code",
+				ColumnKey: "PRI",
+			},
+			expected: `xorm:"pk notnull 'code'"`,
+		},
+		{
+			desc:   "auto_increment column of mysql without primary key gets autoincr only",
+			dbType: settings.DBTypeMySQL,
+			column: database.Column{
+				Name:       "seq",
+				Extra:      "auto_increment",
+				IsNullable: "YES",
+			},
+			expected: `xorm:"autoincr 'seq'"`,
+		},
+		{
+			desc:   "column name with spaces stays a single quoted option",
+			dbType: settings.DBTypeMySQL,
+			column: database.Column{
+				Name:       "first name",
+				IsNullable: "YES",
+			},
+			expected: `xorm:"'first name'"`,
+		},
+	}
+
+	tagger := new(Xorm)
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := settings.New()
+			s.DbType = test.dbType
+			actual := tagger.GenerateTag(database.New(s), test.column)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
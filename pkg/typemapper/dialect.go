@@ -0,0 +1,169 @@
+package typemapper
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Dialect maps the kinds to the names of their data types in a database.
+type Dialect map[Kind][]string
+
+// kindOrder is the order the kinds of a dialect are looked up in.
+var kindOrder = []Kind{KindInteger, KindFloat, KindTemporal, KindBool, KindString, KindText}
+
+// Kind returns the kind of the data type, or false if it is unknown.
+func (d Dialect) Kind(dataType string) (Kind, bool) {
+	for _, kind := range kindOrder {
+		if d.has(kind, dataType) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// has returns true if the data type is of the kind.
+func (d Dialect) has(kind Kind, dataType string) bool {
+	for _, name := range d[kind] {
+		if name == dataType {
+			return true
+		}
+	}
+	return false
+}
+
+// Mapper returns the TypeMapper of the dialect, using the given NULL types
+// for nullable columns.
+func (d Dialect) Mapper(null settings.NullType) TypeMapper {
+	return Func(func(column database.Column) (Type, bool) {
+		kind, ok := d.Kind(column.DataType)
+		if !ok {
+			return Type{}, false
+		}
+		return ForKind(kind, null), true
+	})
+}
+
+// dialectsMu guards dialects against concurrent registrations.
+var dialectsMu sync.RWMutex
+
+// dialects are the dialects of the database types. Registered database types
+// add theirs with RegisterDialect to be mapped by Default.
+var dialects = map[settings.DBType]Dialect{
+	// TODO pg: bitstrings, enum, range, other special types
+	// TODO mysql: bit, enums, set
+	settings.DBTypePostgresql: {
+		KindString: {"character varying", "varchar", "character", "char", "uuid"},
+		KindText:   {"text"},
+		KindInteger: {"smallint", "integer", "bigint", "smallserial", "serial",
+			"bigserial"},
+		KindFloat: {"numeric", "decimal", "real", "double precision"},
+		KindTemporal: {"time", "timestamp", "time with time zone",
+			"timestamp with time zone", "time without time zone",
+			"timestamp without time zone", "date"},
+		KindBool: {"boolean"},
+	},
+	settings.DBTypeMySQL: {
+		KindString:   {"char", "varchar", "binary", "varbinary"},
+		KindText:     {"text", "blob"},
+		KindInteger:  {"tinyint", "smallint", "mediumint", "int", "bigint"},
+		KindFloat:    {"numeric", "decimal", "float", "real", "double precision"},
+		KindTemporal: {"time", "timestamp", "date", "datetime", "year"},
+		KindBool:     {"boolean"},
+	},
+	settings.DBTypeSQLite: {
+		KindString:  {"text"},
+		KindText:    {"text"},
+		KindInteger: {"integer"},
+		KindFloat:   {"real", "numeric"},
+		KindBool:    {"boolean"},
+	},
+	settings.DBTypeMsSQL: {
+		KindString:  {"char", "varchar", "binary", "varbinary"},
+		KindText:    {"text", "blob"},
+		KindInteger: {"tinyint", "smallint", "mediumint", "int", "bigint"},
+		KindFloat:   {"numeric", "decimal", "float", "real"},
+		KindTemporal: {"time", "datetimeoffset", "date", "datetime", "datetime2",
+			"smalldatetime"},
+		KindBool: {"boolean"},
+	},
+	// the data types of the SQL standard
+	settings.DBTypeGeneric: {
+		KindString:  {"character varying", "varchar", "character", "char"},
+		KindText:    {"text", "clob"},
+		KindInteger: {"smallint", "integer", "int", "bigint"},
+		KindFloat:   {"numeric", "decimal", "real", "float", "double precision"},
+		KindTemporal: {"date", "time", "timestamp", "time with time zone",
+			"timestamp with time zone", "time without time zone",
+			"timestamp without time zone"},
+		KindBool: {"boolean"},
+	},
+}
+
+// RegisterDialect adds the dialect of the database type, replacing a dialect
+// registered before. The dialect must not be modified afterwards.
+func RegisterDialect(dbType settings.DBType, dialect Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	dialects[dbType] = dialect
+}
+
+// DialectOf returns a copy of the dialect of the database type, or false if
+// it has none.
+func DialectOf(dbType settings.DBType) (Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	dialect, ok := dialects[dbType]
+	if !ok {
+		return nil, false
+	}
+	dup := make(Dialect, len(dialect))
+	for kind, names := range dialect {
+		dup[kind] = append([]string(nil), names...)
+	}
+	return dup, true
+}
+
+// Generic returns the TypeMapper of the generic database type. It classifies
+// the columns by the Go types their values get scanned into by the driver,
+// or else by the data types of the SQL standard.
+func Generic(null settings.NullType) TypeMapper {
+	dialect, _ := DialectOf(settings.DBTypeGeneric)
+
+	return Func(func(column database.Column) (Type, bool) {
+		scanned := scanKind(column.ValueType())
+		for _, kind := range kindOrder {
+			if kind == scanned || dialect.has(kind, column.DataType) {
+				return ForKind(kind, null), true
+			}
+		}
+		return Type{}, false
+	})
+}
+
+// scanKind returns the kind of the Go type values get scanned into, or an
+// empty kind if it is unknown.
+func scanKind(t reflect.Type) Kind {
+	if t == nil {
+		return ""
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return KindTemporal
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return KindInteger
+	case reflect.Float32, reflect.Float64:
+		return KindFloat
+	case reflect.Bool:
+		return KindBool
+	case reflect.String:
+		return KindString
+	}
+	return ""
+}
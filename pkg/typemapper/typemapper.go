@@ -0,0 +1,108 @@
+// Package typemapper maps the columns of a database to Go types. The default
+// mappers of the database types classify the columns by their data types.
+// They can be overridden and extended by composing mappers with Chain, eg.:
+//
+//	mapper := typemapper.Chain(
+//		typemapper.DataTypes{"uuid": {Kind: typemapper.KindString, Name: "uuid.UUID", Imports: []string{"github.com/google/uuid"}}},
+//		typemapper.Default(settings.DBTypePostgresql, settings.NullTypeSQL),
+//	)
+package typemapper
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+// Kind is the classification of the data type of a column.
+type Kind string
+
+// These are the kinds of data types.
+const (
+	KindInteger  Kind = "integer"
+	KindFloat    Kind = "float"
+	KindTemporal Kind = "temporal"
+	KindBool     Kind = "bool"
+	KindString   Kind = "string"
+	KindText     Kind = "text"
+	KindOther    Kind = "other"
+)
+
+// Type describes the Go type of a column.
+type Type struct {
+	// Kind is the classification of the data type of the column.
+	Kind Kind
+	// Name is the Go type, eg. `int` or `sql.NullInt64`.
+	Name string
+	// Imports are the import paths the Go type needs.
+	Imports []string
+	// Zero is the zero value of the Go type as Go expression, eg. `0`.
+	Zero string
+	// Null is the Go type of nullable columns. If nil, the type is used for
+	// nullable columns as well.
+	Null *Type
+}
+
+// For returns the Go type of a nullable or NOT NULL column.
+func (t Type) For(nullable bool) Type {
+	if nullable && t.Null != nil {
+		return *t.Null
+	}
+	return t
+}
+
+// TypeMapper maps columns to Go types.
+type TypeMapper interface {
+	// MapType returns the Go type of the column and true, or false if the
+	// mapper doesn't know the data type of the column.
+	MapType(column database.Column) (Type, bool)
+}
+
+// Func is a function usable as TypeMapper.
+type Func func(column database.Column) (Type, bool)
+
+// MapType calls the function to satisfy the TypeMapper interface.
+func (f Func) MapType(column database.Column) (Type, bool) {
+	return f(column)
+}
+
+// Chain returns a TypeMapper asking the mappers in the given order. The first
+// mapper knowing the column wins, so overrides come first.
+func Chain(mappers ...TypeMapper) TypeMapper {
+	return Func(func(column database.Column) (Type, bool) {
+		for _, mapper := range mappers {
+			if t, ok := mapper.MapType(column); ok {
+				return t, true
+			}
+		}
+		return Type{}, false
+	})
+}
+
+// DataTypes maps the names of data types to Go types.
+type DataTypes map[string]Type
+
+// MapType returns the Go type of the data type of the column.
+func (m DataTypes) MapType(column database.Column) (Type, bool) {
+	t, ok := m[column.DataType]
+	return t, ok
+}
+
+// Default returns the default TypeMapper of the database type, using the
+// given NULL types for nullable columns. Data types which are unknown to the
+// dialect are mapped to strings. Database types without a dialect get mapped
+// like the generic database type.
+func Default(dbType settings.DBType, null settings.NullType) TypeMapper {
+	dialect, ok := DialectOf(dbType)
+	if !ok || dbType == settings.DBTypeGeneric {
+		return Chain(Generic(null), Fallback(null))
+	}
+	return Chain(dialect.Mapper(null), Fallback(null))
+}
+
+// Fallback returns a TypeMapper mapping every column to a string of the kind
+// KindOther.
+func Fallback(null settings.NullType) TypeMapper {
+	return Func(func(_ database.Column) (Type, bool) {
+		return ForKind(KindOther, null), true
+	})
+}
@@ -0,0 +1,227 @@
+package typemapper
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fraenky8/tables-to-go/pkg/database"
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		desc     string
+		dbType   settings.DBType
+		null     settings.NullType
+		column   database.Column
+		expected Type
+	}{
+		{
+			desc:     "pg integer",
+			dbType:   settings.DBTypePostgresql,
+			null:     settings.NullTypeSQL,
+			column:   database.Column{DataType: "bigint", IsNullable: "NO"},
+			expected: Type{Kind: KindInteger, Name: "int", Zero: "0"},
+		},
+		{
+			desc:     "pg nullable integer as sql type",
+			dbType:   settings.DBTypePostgresql,
+			null:     settings.NullTypeSQL,
+			column:   database.Column{DataType: "bigint", IsNullable: "YES"},
+			expected: Type{Kind: KindInteger, Name: "sql.NullInt64", Zero: "sql.NullInt64{}", Imports: []string{"database/sql"}},
+		},
+		{
+			desc:     "mysql nullable float as null type",
+			dbType:   settings.DBTypeMySQL,
+			null:     settings.NullV4,
+			column:   database.Column{DataType: "decimal", IsNullable: "YES"},
+			expected: Type{Kind: KindFloat, Name: "null.Float", Zero: "null.Float{}", Imports: []string{"gopkg.in/guregu/null.v4"}},
+		},
+		{
+			desc:     "mssql temporal",
+			dbType:   settings.DBTypeMsSQL,
+			null:     settings.NullTypeSQL,
+			column:   database.Column{DataType: "datetime2", IsNullable: "NO"},
+			expected: Type{Kind: KindTemporal, Name: "time.Time", Zero: "time.Time{}", Imports: []string{"time"}},
+		},
+		{
+			desc:     "pg nullable temporal as pointer",
+			dbType:   settings.DBTypePostgresql,
+			null:     settings.NullTypePrimitive,
+			column:   database.Column{DataType: "date", IsNullable: "YES"},
+			expected: Type{Kind: KindTemporal, Name: "*time.Time", Zero: "nil", Imports: []string{"time"}},
+		},
+		{
+			desc:     "sqlite nullable boolean as pointer",
+			dbType:   settings.DBTypeSQLite,
+			null:     settings.NullTypeNative,
+			column:   database.Column{DataType: "boolean", IsNullable: "YES"},
+			expected: Type{Kind: KindBool, Name: "*bool", Zero: "nil"},
+		},
+		{
+			desc:     "pg text",
+			dbType:   settings.DBTypePostgresql,
+			null:     settings.NullTypeSQL,
+			column:   database.Column{DataType: "text", IsNullable: "NO"},
+			expected: Type{Kind: KindText, Name: "string", Zero: `""`},
+		},
+		{
+			desc:     "unknown data type falls back to string",
+			dbType:   settings.DBTypeMySQL,
+			null:     settings.NullTypeSQL,
+			column:   database.Column{DataType: "enum", IsNullable: "YES"},
+			expected: Type{Kind: KindOther, Name: "sql.NullString", Zero: "sql.NullString{}", Imports: []string{"database/sql"}},
+		},
+		{
+			desc:     "database type without dialect is mapped like generic",
+			dbType:   "unknown",
+			null:     settings.NullTypeSQL,
+			column:   database.Column{DataType: "int4", IsNullable: "NO", ScanType: reflect.TypeOf(int32(0))},
+			expected: Type{Kind: KindInteger, Name: "int", Zero: "0"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, ok := Default(test.dbType, test.null).MapType(test.column)
+			assert.True(t, ok)
+
+			actual = actual.For(test.column.IsNullable == "YES")
+			actual.Null = nil
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGeneric(t *testing.T) {
+	tests := []struct {
+		desc     string
+		column   database.Column
+		expected Kind
+		known    bool
+	}{
+		{
+			desc:     "integer scan type",
+			column:   database.Column{DataType: "int8", ScanType: reflect.TypeOf(int64(0))},
+			expected: KindInteger,
+			known:    true,
+		},
+		{
+			desc:     "nullable integer scan type",
+			column:   database.Column{DataType: "int8", ScanType: reflect.TypeOf(sql.NullInt64{})},
+			expected: KindInteger,
+			known:    true,
+		},
+		{
+			desc:     "float pointer scan type",
+			column:   database.Column{DataType: "float8", ScanType: reflect.TypeOf(new(float64))},
+			expected: KindFloat,
+			known:    true,
+		},
+		{
+			desc:     "time scan type",
+			column:   database.Column{DataType: "timestamptz", ScanType: reflect.TypeOf(time.Time{})},
+			expected: KindTemporal,
+			known:    true,
+		},
+		{
+			desc:     "bool scan type",
+			column:   database.Column{DataType: "boolean", ScanType: reflect.TypeOf(sql.NullBool{})},
+			expected: KindBool,
+			known:    true,
+		},
+		{
+			desc:     "string scan type",
+			column:   database.Column{DataType: "string", ScanType: reflect.TypeOf("")},
+			expected: KindString,
+			known:    true,
+		},
+		{
+			desc:     "data type without scan type",
+			column:   database.Column{DataType: "bigint", ScanType: reflect.TypeOf(new(any)).Elem()},
+			expected: KindInteger,
+			known:    true,
+		},
+		{
+			desc:   "unknown data type",
+			column: database.Column{DataType: "blob", ScanType: reflect.TypeOf([]byte{})},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual, ok := Generic(settings.NullTypeSQL).MapType(test.column)
+			assert.Equal(t, test.known, ok)
+			assert.Equal(t, test.expected, actual.Kind)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	uuid := Type{Kind: KindString, Name: "uuid.UUID", Zero: "uuid.UUID{}", Imports: []string{"github.com/google/uuid"}}
+
+	mapper := Chain(
+		DataTypes{"uuid": uuid},
+		Func(func(column database.Column) (Type, bool) {
+			if column.Name == "amount" {
+				return Type{Kind: KindInteger, Name: "int64", Zero: "0"}, true
+			}
+			return Type{}, false
+		}),
+		Default(settings.DBTypePostgresql, settings.NullTypeSQL),
+	)
+
+	actual, ok := mapper.MapType(database.Column{Name: "id", DataType: "uuid"})
+	assert.True(t, ok)
+	assert.Equal(t, uuid, actual)
+
+	actual, ok = mapper.MapType(database.Column{Name: "amount", DataType: "numeric"})
+	assert.True(t, ok)
+	assert.Equal(t, "int64", actual.Name)
+
+	actual, ok = mapper.MapType(database.Column{Name: "price", DataType: "numeric"})
+	assert.True(t, ok)
+	assert.Equal(t, "float64", actual.Name)
+
+	_, ok = Chain(DataTypes{"uuid": uuid}).MapType(database.Column{DataType: "text"})
+	assert.False(t, ok)
+}
+
+func TestType_For(t *testing.T) {
+	withoutNull := Type{Kind: KindOther, Name: "[]byte", Zero: "nil"}
+	assert.Equal(t, withoutNull, withoutNull.For(true))
+
+	withNull := ForKind(KindInteger, settings.NullTypeSQL)
+	assert.Equal(t, "int", withNull.For(false).Name)
+	assert.Equal(t, "sql.NullInt64", withNull.For(true).Name)
+}
+
+func TestRegisterDialect(t *testing.T) {
+	const dbType settings.DBType = "test"
+	defer func() {
+		dialectsMu.Lock()
+		delete(dialects, dbType)
+		dialectsMu.Unlock()
+	}()
+
+	_, ok := DialectOf(dbType)
+	assert.False(t, ok)
+
+	RegisterDialect(dbType, Dialect{KindInteger: {"number"}})
+
+	dialect, ok := DialectOf(dbType)
+	assert.True(t, ok)
+	assert.Equal(t, Dialect{KindInteger: {"number"}}, dialect)
+
+	actual, ok := Default(dbType, settings.NullTypeSQL).MapType(database.Column{DataType: "number", IsNullable: "NO"})
+	assert.True(t, ok)
+	assert.Equal(t, "int", actual.Name)
+
+	// the returned dialect is a copy
+	dialect[KindInteger][0] = "text"
+	dialect[KindText] = []string{"number"}
+	dialect, _ = DialectOf(dbType)
+	assert.Equal(t, Dialect{KindInteger: {"number"}}, dialect)
+}
@@ -0,0 +1,52 @@
+package typemapper
+
+import (
+	"github.com/fraenky8/tables-to-go/pkg/settings"
+)
+
+const (
+	sqlImport  = "database/sql"
+	nullImport = "gopkg.in/guregu/null.v4"
+	timeImport = "time"
+)
+
+// ForKind returns the Go type of the kind. Nullable columns are either
+// sql.Null*, null.* or pointer types depending on the NULL types.
+func ForKind(kind Kind, null settings.NullType) Type {
+	t := Type{Kind: kind}
+
+	switch kind {
+	case KindInteger:
+		t.Name, t.Zero = "int", "0"
+		t.Null = nullType(kind, null, "*int", "sql.NullInt64", "null.Int")
+	case KindFloat:
+		t.Name, t.Zero = "float64", "0"
+		t.Null = nullType(kind, null, "*float64", "sql.NullFloat64", "null.Float")
+	case KindTemporal:
+		t.Name, t.Zero, t.Imports = "time.Time", "time.Time{}", []string{timeImport}
+		t.Null = nullType(kind, null, "*time.Time", "sql.NullTime", "null.Time")
+		if t.Null.Name == "*time.Time" {
+			t.Null.Imports = []string{timeImport}
+		}
+	case KindBool:
+		t.Name, t.Zero = "bool", "false"
+		t.Null = nullType(kind, null, "*bool", "sql.NullBool", "null.Bool")
+	default:
+		t.Name, t.Zero = "string", `""`
+		t.Null = nullType(kind, null, "*string", "sql.NullString", "null.String")
+	}
+
+	return t
+}
+
+// nullType returns the Go type of nullable columns for the NULL types.
+func nullType(kind Kind, null settings.NullType, primitive, sql, guregu string) *Type {
+	switch null {
+	case settings.NullTypeSQL:
+		return &Type{Kind: kind, Name: sql, Zero: sql + "{}", Imports: []string{sqlImport}}
+	case settings.NullV4:
+		return &Type{Kind: kind, Name: guregu, Zero: guregu + "{}", Imports: []string{nullImport}}
+	default:
+		return &Type{Kind: kind, Name: primitive, Zero: "nil"}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -29,6 +30,8 @@ func NewCmdArgs() (args *CmdArgs) {
 	flag.BoolVar(&args.Verbose, "v", args.Verbose, "verbose output")
 	flag.BoolVar(&args.VVerbose, "vv", args.VVerbose, "more verbose output")
 	flag.BoolVar(&args.Force, "f", args.Force, "force; skip tables that encounter errors")
+	flag.Var(&args.LogFormat, "log-format", "format of the log output on stderr: text (default) or json")
+	flag.StringVar(&args.Report, "report", args.Report, "path of a JSON report of the run with the status, number of columns, file, content hash, warnings and error per table, view, query or SQL file")
 
 	flag.Var(&args.DbType, "t", fmt.Sprintf("type of database to use, currently supported: %v", settings.SprintfSupportedDbTypes()))
 	flag.StringVar(&args.User, "u", args.User, "user to connect to the database")
@@ -38,6 +41,10 @@ func NewCmdArgs() (args *CmdArgs) {
 	flag.StringVar(&args.Host, "h", args.Host, "host of database")
 	flag.StringVar(&args.Port, "port", args.Port, "port of database host, if not specified, it will be the default ports for the supported databases")
 	flag.StringVar(&args.SSLMode, "sslmode", args.SSLMode, "Connect to database using secure connection. (default \"disable\")\nThe value will be passed as is to the underlying driver.\nRefer to this site for supported values: https://www.postgresql.org/docs/current/libpq-ssl.html")
+	flag.StringVar(&args.Driver, "driver", args.Driver, "database/sql driver of the database type generic, e.g. postgres for CockroachDB")
+	flag.StringVar(&args.DataSource, "dsn", args.DataSource, "data source name of the database type generic, passed as is to the driver")
+	flag.Var(&args.Dialect, "dialect", fmt.Sprintf("SQL dialect of the database type generic for the generated queries, one of: %v, e.g. pg for CockroachDB", settings.SprintfSupportedDialects()))
+	flag.StringVar(&args.TablesQuery, "tables-query", args.TablesQuery, "query of the table names of the database type generic, the first column is the name (default information_schema.tables of the schema)")
 	flag.StringVar(&args.Socket, "socket", args.Socket, "The socket file to use for connection. If specified, takes precedence over host:port.")
 
 	flag.StringVar(&args.OutputFilePath, "of", args.OutputFilePath, "output file path, default is current working directory")
@@ -47,9 +54,15 @@ func NewCmdArgs() (args *CmdArgs) {
 	flag.StringVar(&args.Prefix, "pre", args.Prefix, "prefix for file- and struct names")
 	flag.StringVar(&args.Suffix, "suf", args.Suffix, "suffix for file- and struct names")
 	flag.StringVar(&args.PackageName, "pn", args.PackageName, "package name")
+	flag.StringVar(&args.Template, "template", args.Template, "path of a text/template file to render the struct files with instead of the built-in template")
+	flag.Var(&args.StructNameInflection, "inflection", "inflection of the table names for struct names: none (default), singular or plural")
+	flag.Var(&args.FileNameInflection, "fn-inflection", "inflection of the table names for file names: none (default), singular or plural")
+	flag.Var(&args.Irregulars, "irregulars", "comma separated list of irregular inflections as singular=plural pairs, e.g. person=people,cactus=cacti")
+	flag.Var(&args.Renames, "rename", "comma separated list of explicit Go names as table=Name (struct and file name) or table.column=Name (field name) pairs")
 	flag.Var(&args.Null, "null", "representation of NULL columns: sql.Null* (sql) or primitive pointers (native|primitive) or null.v4")
 
 	flag.BoolVar(&args.NoInitialism, "no-initialism", args.NoInitialism, "disable the conversion to upper-case words in column names")
+	flag.Var(&args.Initialisms, "initialisms", "comma separated list of additional initialisms to upper-case in column names, e.g. SKU,IBAN")
 
 	flag.BoolVar(&args.TagsNoDb, "tags-no-db", args.TagsNoDb, "do not create db-tags")
 
@@ -57,6 +70,37 @@ func NewCmdArgs() (args *CmdArgs) {
 	flag.BoolVar(&args.TagsMastermindStructableOnly, "tags-structable-only", args.TagsMastermindStructableOnly, "generate struct with tags ONLY for use in Masterminds/structable (https://github.com/Masterminds/structable)")
 	flag.BoolVar(&args.IsMastermindStructableRecorder, "structable-recorder", args.IsMastermindStructableRecorder, "generate a structable.Recorder field")
 
+	flag.BoolVar(&args.TagsGorm, "tags-gorm", args.TagsGorm, "generate struct with tags and TableName() methods for use in GORM (https://gorm.io)")
+
+	flag.BoolVar(&args.TagsJSON, "tags-json", args.TagsJSON, "generate struct with json-tags")
+	flag.Var(&args.JSONNaming, "tags-json-naming", "naming of the json-tags: original column name (o, default), snake_case (s) or camelCase (c)")
+	flag.Var(&args.JSONOmitEmpty, "tags-json-omitempty", "when to add omitempty to the json-tags: never (default), nullable (only nullable columns) or always")
+
+	flag.BoolVar(&args.TagsValidate, "tags-validate", args.TagsValidate, "generate struct with validate-tags derived from the schema for use in go-playground/validator (https://github.com/go-playground/validator)")
+	flag.Var(&args.ValidateRules, "validate-rules", "validation rules as table=rules or table.column=rules pair overriding the derived ones, \"-\" omits the tag, e.g. users.email=required,email; can be given multiple times")
+
+	flag.BoolVar(&args.TagsBun, "tags-bun", args.TagsBun, "generate struct with tags and a bun.BaseModel field for use in uptrace/bun (https://github.com/uptrace/bun)")
+	flag.BoolVar(&args.TagsXorm, "tags-xorm", args.TagsXorm, "generate struct with tags for use in xorm (https://xorm.io)")
+
+	flag.BoolVar(&args.GenerateColumns, "columns", args.GenerateColumns, "generate a TableName() method, a variable with the column names and a slice with all column names in their order per struct")
+
+	flag.BoolVar(&args.GenerateRepository, "repository", args.GenerateRepository, "generate sqlx based Get, List, Insert, Update and Delete functions per struct (https://github.com/jmoiron/sqlx)")
+
+	flag.BoolVar(&args.GenerateScan, "scan", args.GenerateScan, "generate ScanRow() and Values() methods per struct to scan and insert rows without reflection")
+	flag.BoolVar(&args.GenerateUpsert, "upsert", args.GenerateUpsert, "generate an upsert query and function per struct with a primary key or unique column")
+	flag.Var(&args.UpsertExclude, "upsert-exclude", "comma separated list of columns as column (all tables) or table.column neither inserted nor updated by the upsert, e.g. created_at")
+
+	flag.BoolVar(&args.GenerateDescriptor, "descriptor", args.GenerateDescriptor, "generate a table descriptor per struct for the generic repository of the package github.com/fraenky8/tables-to-go/pkg/runtime")
+	flag.BoolVar(&args.GenerateTypedColumns, "typed-columns", args.GenerateTypedColumns, "generate typed columns per struct to build queries with the package github.com/fraenky8/tables-to-go/pkg/runtime, e.g. UsersTable.Select().Where(UsersTable.Email.Eq(email))")
+
+	flag.Var(&args.SQLFiles, "sql", "comma separated list of SQL files or directories with queries annotated by '-- name: GetUserByEmail :one' (:one, :many, :exec or :execrows) to generate typed functions for instead of the structs of the tables")
+
+	flag.StringVar(&args.Query, "query", args.Query, "SELECT statement to generate a struct of its result columns for instead of the structs of the tables; needs -name")
+	flag.StringVar(&args.QueryName, "name", args.QueryName, "name of the struct of -query, e.g. MonthlyReport")
+
+	flag.Var(&args.TagTemplates, "tag-template", "user-defined tag as text/template executed with .Column, .Table, .Field and .DB, e.g. 'bson:\"{{snake .Column.Name}},omitempty\"'; can be given multiple times")
+	flag.Var(&args.TagOrder, "tag-order", "comma separated list of tag keys in the order to generate them, e.g. json,db; remaining tags follow in default order")
+
 	// disable the print of usage when an error occurs
 	flag.CommandLine.Usage = func() {}
 
@@ -75,22 +119,28 @@ func main() {
 		os.Exit(0)
 	}
 
+	cmdArgs.Logger = cmdArgs.NewLogger(os.Stderr)
+
 	if err := cmdArgs.Verify(); err != nil {
-		fmt.Print(err)
+		cmdArgs.Logger.Error("invalid settings", "error", err)
 		os.Exit(1)
 	}
 
 	db := database.New(cmdArgs.Settings)
 
 	if err := db.Connect(); err != nil {
-		fmt.Println(err)
+		cmdArgs.Logger.Error("could not connect to database", "error", err)
 		os.Exit(1)
 	}
 
 	writer := output.NewFileWriter(cmdArgs.OutputFilePath)
 
 	if err := cli.Run(cmdArgs.Settings, db, writer); err != nil {
-		fmt.Printf("run error: %v\n", err)
+		if errors.Is(err, cli.ErrPartialFailure) {
+			cmdArgs.Logger.Warn("run finished partially", "error", err)
+			os.Exit(3)
+		}
+		cmdArgs.Logger.Error("run error", "error", err)
 		os.Exit(1)
 	}
 }